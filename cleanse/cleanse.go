@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bradfitz/slice"
 	"github.com/flowcommerce/json-reference/common"
@@ -41,17 +42,39 @@ type CountryContinent struct {
 	CountryCode   string `json:"country"`
 }
 
+type CountryTelephone struct {
+	CountryCode           string   `json:"country"`
+	CallingCode           string   `json:"calling_code"`
+	TrunkPrefix           string   `json:"trunk_prefix,omitempty"`
+	NationalNumberLengths []int    `json:"national_number_lengths"`
+	ExampleNumber         string   `json:"example_number"`
+	AreaCodes             []string `json:"area_codes,omitempty"`
+}
+
 type CountryDuty struct {
 	CountryCode   string `json:"country"`
 	DeliveredDuty string `json:"duty"`
 }
 
 type Country struct {
-	Name       string `json:"name"`
-	Continent  string `json:"continent"`
-	Iso_3166_2 string `json:"iso_3166_2"`
-	Iso_3166_3 string `json:"iso_3166_3"`
-	Currency   string `json:"currency"`
+	Name           string   `json:"name"`
+	Continent      string   `json:"continent"`
+	Iso_3166_2     string   `json:"iso_3166_2"`
+	Iso_3166_3     string   `json:"iso_3166_3"`
+	Currency       string   `json:"currency"`
+	CurrencyReason string   `json:"currency_reason"`
+	LocalCurrency  string   `json:"local_currency"`
+	Currencies     []string `json:"currencies"`
+	Iso_3166_N     string   `json:"iso_3166_numeric,omitempty"`
+	M49            string   `json:"m49,omitempty"`
+	Fips           string   `json:"fips,omitempty"`
+	Ioc            string   `json:"ioc,omitempty"`
+	Fifa           string   `json:"fifa,omitempty"`
+	Itu            string   `json:"itu,omitempty"`
+	DialingCode    string   `json:"dialing_code,omitempty"`
+	Tld            string   `json:"tld,omitempty"`
+	Capital        string   `json:"capital,omitempty"`
+	GeonameId      int      `json:"geoname_id,omitempty"`
 }
 
 type Currency struct {
@@ -60,16 +83,38 @@ type Currency struct {
 	NumberDecimals int    `json:"number_decimals"`
 }
 
+type CurrencyRemapping struct {
+	Profile        string `json:"profile"`
+	CurrencyCode   string `json:"currency"`
+	TargetCurrency string `json:"target"`
+	Rationale      string `json:"rationale"`
+}
+
 type CurrencyLocale struct {
 	CurrencyCode string `json:"currency"`
 	LocaleId     string `json:"locale"`
 }
 
+type CountryLocale struct {
+	CountryCode string `json:"country"`
+	LocaleId    string `json:"locale"`
+}
+
+type LanguageLocale struct {
+	LanguageCode string `json:"language"`
+	LocaleId     string `json:"locale"`
+}
+
 type Language struct {
-	Name      string   `json:"name"`
-	Iso_639_2 string   `json:"iso_639_2"`
-	Countries []string `json:"countries"`
-	Locales   []string `json:"locales"`
+	Name       string   `json:"name"`
+	Iso_639_1  string   `json:"iso_639_1"`
+	Iso_639_2B string   `json:"iso_639_2b"`
+	Iso_639_2T string   `json:"iso_639_2t"`
+	Iso_639_3  string   `json:"iso_639_3"`
+	Script     string   `json:"script,omitempty"`
+	Direction  string   `json:"direction"`
+	Countries  []string `json:"countries"`
+	Locales    []string `json:"locales"`
 }
 
 type LocaleName struct {
@@ -91,14 +136,28 @@ type ProvinceTranslation struct {
 }
 
 type Timezone struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Offset      int    `json:"offset"`
+	Name                 string               `json:"name"`
+	Description          string               `json:"description"`
+	Offset               int                  `json:"offset"`
+	DaylightOffset       *int                 `json:"daylight_offset,omitempty"`
+	Abbreviation         string               `json:"abbreviation,omitempty"`
+	DaylightAbbreviation string               `json:"daylight_abbreviation,omitempty"`
+	Aliases              []string             `json:"aliases,omitempty"`
+	Rule                 string               `json:"rule,omitempty"`
+	Year                 int                  `json:"year,omitempty"`
+	Transitions          []TimezoneTransition `json:"transitions,omitempty"`
 }
 
 type CountryTimezone struct {
-	TimezoneCode string `json:"timezone"`
-	CountryCode  string `json:"country"`
+	TimezoneCode string       `json:"timezone"`
+	CountryCode  string       `json:"country"`
+	Coordinates  *Coordinates `json:"coordinates,omitempty"`
+	Comments     string       `json:"comments,omitempty"`
+}
+
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 type CountryDefaultLanguage struct {
@@ -112,10 +171,65 @@ type IncomingLanguages struct {
 }
 
 type IncomingLanguage struct {
-	Iso_639_2 string                   `json:"iso639_1"`
-	Names     []string                 `json:"name"`
-	Countries []string                 `json:"countries"`
-	Locales   []IncomingLanguageLocale `json:"langCultureMs"`
+	Iso_639_1  string                   `json:"iso639_1"`
+	Iso_639_2B string                   `json:"iso639_2en"`
+	Iso_639_2T string                   `json:"iso639_2"`
+	Iso_639_3  string                   `json:"iso639_3"`
+	Names      []string                 `json:"name"`
+	Direction  string                   `json:"direction"`
+	Countries  []string                 `json:"countries"`
+	Locales    []IncomingLanguageLocale `json:"langCultureMs"`
+}
+
+type LanguageTagAliases struct {
+	Languages map[string]string `json:"languages"`
+	Scripts   map[string]string `json:"scripts"`
+	Regions   map[string]string `json:"regions"`
+	Variants  map[string]string `json:"variants"`
+}
+
+type CldrAliases struct {
+	Supplemental CldrAliasesSupplemental `json:"supplemental"`
+}
+
+type CldrAliasesSupplemental struct {
+	Metadata CldrAliasesMetadata `json:"metadata"`
+}
+
+type CldrAliasesMetadata struct {
+	Alias CldrAlias `json:"alias"`
+}
+
+type CldrAlias struct {
+	LanguageAlias  map[string]CldrAliasReplacement `json:"languageAlias"`
+	ScriptAlias    map[string]CldrAliasReplacement `json:"scriptAlias"`
+	TerritoryAlias map[string]CldrAliasReplacement `json:"territoryAlias"`
+	VariantAlias   map[string]CldrAliasReplacement `json:"variantAlias"`
+}
+
+type CldrAliasReplacement struct {
+	Replacement string `json:"_replacement"`
+	Reason      string `json:"_reason"`
+}
+
+type CldrParentLocales struct {
+	Supplemental CldrParentLocalesSupplemental `json:"supplemental"`
+}
+
+type CldrParentLocalesSupplemental struct {
+	ParentLocales CldrParentLocalesData `json:"parentLocales"`
+}
+
+type CldrParentLocalesData struct {
+	ParentLocale map[string]string `json:"parentLocale"`
+}
+
+type CldrLikelySubtags struct {
+	Supplemental CldrLikelySubtagsSupplemental `json:"supplemental"`
+}
+
+type CldrLikelySubtagsSupplemental struct {
+	LikelySubtags map[string]string `json:"likelySubtags"`
 }
 
 type IncomingLanguageLocale struct {
@@ -140,11 +254,18 @@ type IncomingNumbersMain struct {
 
 type CldrIdentity struct {
 	Language  string `json:"language"`
+	Script    string `json:"script"`
 	Territory string `json:"territory"`
+	Variant   string `json:"variant"`
 }
 
 type IncomingNumbersNumbers struct {
-	Symbols IncomingNumbersSymbols `json:"symbols-numberSystem-latn"`
+	Symbols         IncomingNumbersSymbols         `json:"symbols-numberSystem-latn"`
+	CurrencyFormats IncomingNumbersCurrencyFormats `json:"currencyFormats-numberSystem-latn"`
+}
+
+type IncomingNumbersCurrencyFormats struct {
+	Standard string `json:"standard"`
 }
 
 type IncomingNumbersSymbols struct {
@@ -153,9 +274,13 @@ type IncomingNumbersSymbols struct {
 }
 
 type Number struct {
-	Country    string     `json:"country"`
-	Language   string     `json:"language"`
-	Separators Separators `json:"separators"`
+	Country        string     `json:"country"`
+	Language       string     `json:"language"`
+	Script         string     `json:"script,omitempty"`
+	Region         string     `json:"region,omitempty"`
+	Variant        string     `json:"variant,omitempty"`
+	Separators     Separators `json:"separators"`
+	CurrencyFormat string     `json:"currency_format,omitempty"`
 }
 
 type PaymentMethod struct {
@@ -198,6 +323,100 @@ type CldrIncomingCurrency struct {
 	SymbolAltNarrow string `json:"symbol-alt-narrow,omitempty"`
 }
 
+type CldrWindowsZones struct {
+	Supplemental CldrWindowsZonesSupplemental `json:"supplemental"`
+}
+
+type CldrWindowsZonesSupplemental struct {
+	WindowsZones CldrWindowsZonesMapTimezones `json:"windowsZones"`
+}
+
+type CldrWindowsZonesMapTimezones struct {
+	MapTimezones []CldrWindowsZonesMapZone `json:"mapTimezones"`
+}
+
+type CldrWindowsZonesMapZone struct {
+	MapZone CldrWindowsZone `json:"mapZone"`
+}
+
+type CldrWindowsZone struct {
+	Other     string `json:"_other"`
+	Type      string `json:"_type"`
+	Territory string `json:"_territory"`
+}
+
+type WindowsZone struct {
+	WindowsId string   `json:"windows_id"`
+	Territory string   `json:"territory"`
+	Timezones []string `json:"timezones"`
+}
+
+type CldrCurrencyData struct {
+	Supplemental CldrCurrencyDataSupplemental `json:"supplemental"`
+}
+
+type CldrCurrencyDataSupplemental struct {
+	CurrencyData CldrCurrencyDataCurrencyData `json:"currencyData"`
+}
+
+type CldrCurrencyDataCurrencyData struct {
+	Fractions map[string]CldrCurrencyFraction            `json:"fractions"`
+	Region    map[string][]map[string]CldrCurrencyTenure `json:"region"`
+}
+
+type CldrCurrencyTenure struct {
+	From   string `json:"_from,omitempty"`
+	To     string `json:"_to,omitempty"`
+	Tender string `json:"_tender,omitempty"`
+}
+
+type CountryCurrency struct {
+	CountryCode  string `json:"country"`
+	CurrencyCode string `json:"currency"`
+	From         string `json:"from,omitempty"`
+	To           string `json:"to,omitempty"`
+	Tender       bool   `json:"tender"`
+}
+
+type CountryLanguage struct {
+	CountryCode       string  `json:"country"`
+	LanguageCode      string  `json:"language"`
+	PopulationPercent float64 `json:"population_percent"`
+	OfficialStatus    string  `json:"official_status,omitempty"`
+}
+
+type CldrTerritoryInfo struct {
+	Supplemental CldrTerritoryInfoSupplemental `json:"supplemental"`
+}
+
+type CldrTerritoryInfoSupplemental struct {
+	TerritoryInfo map[string]CldrTerritory `json:"territoryInfo"`
+}
+
+type CldrTerritory struct {
+	Population         string                           `json:"_population"`
+	LanguagePopulation map[string]CldrTerritoryLanguage `json:"languagePopulation"`
+}
+
+type CldrTerritoryLanguage struct {
+	PopulationPercent string `json:"_populationPercent"`
+	OfficialStatus    string `json:"_officialStatus"`
+}
+
+type CldrCurrencyFraction struct {
+	Digits       string `json:"_digits"`
+	Rounding     string `json:"_rounding"`
+	CashDigits   string `json:"_cashDigits,omitempty"`
+	CashRounding string `json:"_cashRounding,omitempty"`
+}
+
+type CurrencyFraction struct {
+	Digits       int `json:"digits"`
+	Rounding     int `json:"rounding"`
+	CashDigits   int `json:"cash_digits"`
+	CashRounding int `json:"cash_rounding"`
+}
+
 type CldrCurrency struct {
 	Iso_4217_3 string          `json:"iso_4217_3"`
 	Symbols    CurrencySymbols `json:"symbols"`
@@ -213,14 +432,24 @@ type acceptsFunction func(records map[string]string) bool
 type idFunction func(records map[string]string) string
 
 func Cleanse() {
-	languages, localeNames := readLanguages("data/source/languages.json")
+	likelySubtags := readLikelySubtags("data/source/cldr-likely-subtags.json")
+	languages, localeNames := readLanguages("data/source/languages.json", likelySubtags)
 	writeJson("data/cleansed/languages.json", languages)
 	writeJson("data/cleansed/locale-names.json", localeNames)
 
 	unsupportedCountryCodes := common.UnsupportedCountryCodes()
 
+	currencyRemappings := readCurrencyRemappings("data/original/currency-remappings.csv")
+	writeJson("data/cleansed/currency-remappings.json", currencyRemappings)
+
+	remappingProfiles := ToCurrencyRemappingProfiles(currencyRemappings)
+
 	countriesSource := readCsv("data/source/countries.csv")
-	writeJson("data/cleansed/countries.json",
+	alpha3 := map[string]string{}
+	for _, record := range countriesSource {
+		alpha3[record["ISO3166-1-Alpha-2"]] = record["ISO3166-1-Alpha-3"]
+	}
+	writeJsonAs("data/cleansed/countries.json", []Country{},
 		toObjects(countriesSource,
 			func(record map[string]string) bool {
 				return record["ISO3166-1-Alpha-2"] != "" && record["ISO3166-1-Alpha-3"] != "" && !common.ContainsIgnoreCase(unsupportedCountryCodes, record["ISO3166-1-Alpha-3"])
@@ -228,14 +457,29 @@ func Cleanse() {
 			func(record map[string]string) interface{} {
 				iso3 := record["ISO3166-1-Alpha-3"]
 				currency := record["ISO4217-currency_alphabetic_code"]
+				currencies := common.FilterNonEmpty(strings.Split(currency, ","))
+
+				// For countries with several official currencies, 'currency' is the one
+				// we settle in and 'localCurrency' the one in everyday use
+				var localCurrency string
 				if currency == "INR,BTN" {
 					currency = "INR"
-				} else if currency == "SVC,USD" || currency == "HTG,USD" || currency == "PAB,USD" {
+					localCurrency = "BTN"
+				} else if currency == "SVC,USD" {
+					currency = "USD"
+					localCurrency = "USD"
+				} else if currency == "HTG,USD" || currency == "PAB,USD" {
+					localCurrency = currencies[0]
 					currency = "USD"
 				} else if currency == "LSL,ZAR" {
 					currency = "ZAR"
+					localCurrency = "LSL"
 				} else if currency == "NAD,ZAR" {
 					currency = "NAD"
+					localCurrency = "NAD"
+				} else if currency == "CUP,CUC" {
+					currency = "CUP"
+					localCurrency = "CUP"
 				} else if currency == "" {
 					if iso3 == "CZE" {
 						currency = "CZK"
@@ -257,18 +501,42 @@ func Cleanse() {
 					}
 				}
 
-				finalCurrency := common.RemapCurrencyCodeToSupported(currency)
-				if finalCurrency == "" {
-					fmt.Printf("Currency %s could not be remapped\n", currency)
-					os.Exit(1)
+				if len(currencies) == 0 {
+					currencies = []string{currency}
+				}
+				if localCurrency == "" {
+					localCurrency = strings.Split(currency, ",")[0]
+				}
+
+				finalCurrency, err := common.RemapCurrency(remappingProfiles, common.DefaultCurrencyRemappingProfile, currency)
+				util.ExitIfError(err, fmt.Sprintf("Failed to remap currency[%s]: %s", currency, err))
+
+				currencyReason := common.DefaultCurrencyReasonLocal
+				if finalCurrency != currency {
+					currencyReason = common.DefaultCurrencyReasonUnsupported
+				} else if finalCurrency != localCurrency {
+					currencyReason = common.DefaultCurrencyReasonCirculating
 				}
 
 				return Country{
-					Name:       countryName(record),
-					Iso_3166_2: record["ISO3166-1-Alpha-2"],
-					Iso_3166_3: iso3,
-					Currency:   finalCurrency,
-					Continent:  record["Continent"],
+					Name:           countryName(record),
+					Iso_3166_2:     record["ISO3166-1-Alpha-2"],
+					Iso_3166_3:     iso3,
+					Currency:       finalCurrency,
+					CurrencyReason: currencyReason,
+					LocalCurrency:  localCurrency,
+					Currencies:     currencies,
+					Continent:      record["Continent"],
+					Iso_3166_N:     countryIdentifier(record, "ISO3166-1-numeric"),
+					M49:            countryIdentifier(record, "M49"),
+					Fips:           countryIdentifier(record, "FIPS"),
+					Ioc:            countryIdentifier(record, "IOC"),
+					Fifa:           countryIdentifier(record, "FIFA"),
+					Itu:            countryIdentifier(record, "ITU"),
+					DialingCode:    countryIdentifier(record, "Dial"),
+					Tld:            countryIdentifier(record, "TLD"),
+					Capital:        countryIdentifier(record, "Capital"),
+					GeonameId:      geonameId(record),
 				}
 			},
 			func(record map[string]string) string {
@@ -283,10 +551,25 @@ func Cleanse() {
 	currencySymbols := readCurrencySymbols("data/source/cldr-currencies.json")
 	writeJson("data/cleansed/currency-symbols.json", currencySymbols)
 
+	windowsZones := readWindowsZones("data/source/cldr-windows-zones.json")
+	writeJson("data/cleansed/windows-zones.json", windowsZones)
+
+	currencyFractions := readCurrencyFractions("data/source/cldr-currency-data.json")
+	writeJson("data/cleansed/currency-fractions.json", currencyFractions)
+
+	countryCurrencies := readCountryCurrencies("data/source/cldr-currency-data.json", alpha3)
+	writeJson("data/cleansed/country-currencies.json", countryCurrencies)
+
+	writeJson("data/cleansed/country-languages.json", readCountryLanguages("data/source/cldr-territory-info.json", alpha3, languages))
+	writeJson("data/cleansed/country-populations.json", readCountryPopulations("data/source/cldr-territory-info.json", alpha3))
+
 	currencies := readCurrencies("data/original/currencies.json")
 	writeJson("data/cleansed/currencies.json", currencies)
+	historicCurrencies := readHistoricCurrencies(currencies, countryCurrencies, "data/source/cldr-currencies.json", currencyFractions)
+	writeJson("data/cleansed/historic-currencies.json", historicCurrencies)
+	writeJson("data/cleansed/locale-currency-symbols.json", loadCldrLocaleCurrencySymbols("cldr-numbers-full/main", languages, append(append([]Currency{}, currencies...), historicCurrencies...)))
 
-	writeJson("data/cleansed/country-duties.json",
+	writeJsonAs("data/cleansed/country-duties.json", []CountryDuty{},
 		toObjects(readCsv("data/original/country-duties.csv"),
 			func(record map[string]string) bool {
 				return record["duty"] != ""
@@ -303,7 +586,33 @@ func Cleanse() {
 		),
 	)
 
-	writeJson("data/cleansed/carriers.json",
+	writeJsonAs("data/cleansed/country-telephones.json", []CountryTelephone{},
+		toObjects(readCsv("data/original/country-telephones.csv"),
+			func(record map[string]string) bool {
+				return record["country"] != "" && record["calling_code"] != ""
+			},
+			func(record map[string]string) interface{} {
+				lengths := []int{}
+				for _, l := range strings.Fields(record["national_number_lengths"]) {
+					lengths = append(lengths, toInt32(l))
+				}
+
+				return CountryTelephone{
+					CountryCode:           strings.ToUpper(record["country"]),
+					CallingCode:           record["calling_code"],
+					TrunkPrefix:           record["trunk_prefix"],
+					NationalNumberLengths: lengths,
+					ExampleNumber:         record["example"],
+					AreaCodes:             strings.Fields(record["area_codes"]),
+				}
+			},
+			func(record map[string]string) string {
+				return record["country"]
+			},
+		),
+	)
+
+	writeJsonAs("data/cleansed/carriers.json", []Carrier{},
 		toObjects(readCsv("data/original/carriers.csv"),
 			func(record map[string]string) bool {
 				return record["id"] != ""
@@ -321,7 +630,7 @@ func Cleanse() {
 		),
 	)
 
-	writeJson("data/cleansed/carrier-services.json",
+	writeJsonAs("data/cleansed/carrier-services.json", []CarrierService{},
 		toObjects(readCsv("data/original/carrier-services.csv"),
 			func(record map[string]string) bool {
 				return record["id"] != ""
@@ -339,7 +648,7 @@ func Cleanse() {
 		),
 	)
 
-	writeJson("data/cleansed/provinces.json",
+	writeJsonAs("data/cleansed/provinces.json", []Province{},
 		toObjects(readCsv("data/original/provinces.csv"),
 			func(record map[string]string) bool {
 				return record["province"] != ""
@@ -358,7 +667,7 @@ func Cleanse() {
 		),
 	)
 
-	writeJson("data/cleansed/province-translations.json",
+	writeJsonAs("data/cleansed/province-translations.json", []ProvinceTranslation{},
 		toObjects(readCsv("data/original/province-translations.csv"),
 			func(record map[string]string) bool {
 				return record["province_id"] != ""
@@ -376,7 +685,7 @@ func Cleanse() {
 		),
 	)
 
-	writeJson("data/cleansed/country-continents.json",
+	writeJsonAs("data/cleansed/country-continents.json", []CountryContinent{},
 		toObjects(readCsv("data/source/country-continents.csv"),
 			func(record map[string]string) bool {
 				return record["continent code"] != "" && record["continent code"] != "--"
@@ -396,7 +705,7 @@ func Cleanse() {
 	splitCapabilities := func(c rune) bool {
 		return c == ' '
 	}
-	writeJson("data/cleansed/payment-methods.json",
+	writeJsonAs("data/cleansed/payment-methods.json", []PaymentMethod{},
 		toObjects(readCsv("data/original/payment-methods.csv"),
 			func(record map[string]string) bool {
 				return record["id"] != ""
@@ -422,26 +731,18 @@ func Cleanse() {
 		),
 	)
 
-	writeJson("data/cleansed/timezones.json", loadTimezonesFromPath("data/original/timezones.json"))
+	writeJson("data/cleansed/timezones.json", readTimezones(zoneinfoPath(), loadTimezonesFromPath("data/original/timezones.json")))
 
-	writeJson("data/cleansed/country-timezones.json",
-		toObjects(readCsv("data/original/country-timezones.csv"),
-			func(record map[string]string) bool {
-				return record["country"] != "" && record["timezone"] != ""
-			},
-			func(record map[string]string) interface{} {
-				return CountryTimezone{
-					TimezoneCode: record["timezone"],
-					CountryCode:  strings.ToUpper(record["country"]),
-				}
-			},
-			func(record map[string]string) string {
-				return record["timezone"] + record["country"]
-			},
-		),
-	)
+	writeJson("data/cleansed/timezone-metazones.json", readMetazones("data/source/cldr-meta-zones.json"))
+	writeJson("data/cleansed/timezone-names.json", loadCldrTimezoneNames("cldr-dates-full/main"))
+	writeJson("data/cleansed/parent-locales.json", readParentLocales("data/source/cldr-parent-locales.json"))
+	writeJson("data/cleansed/likely-subtags.json", likelySubtags)
+	writeJson("data/cleansed/language-tag-aliases.json", readLanguageTagAliases("data/source/cldr-aliases.json"))
+	writeJson("data/cleansed/locale-display-names.json", loadCldrLocaleDisplayNames("cldr-localenames-full/main", languages, alpha3))
+
+	writeJson("data/cleansed/country-timezones.json", readCountryTimezones(zoneinfoPath(), alpha3, readCsv("data/original/country-timezones.csv")))
 
-	writeJson("data/cleansed/country-default-languages.json",
+	writeJsonAs("data/cleansed/country-default-languages.json", []CountryDefaultLanguage{},
 		toObjects(readCsv("data/original/country-default-languages.csv"),
 			func(record map[string]string) bool {
 				return true
@@ -458,7 +759,7 @@ func Cleanse() {
 		),
 	)
 
-	writeJson("data/cleansed/currency-locales.json",
+	writeJsonAs("data/cleansed/currency-locales.json", []CurrencyLocale{},
 		toObjects(readCsv("data/original/currency-locales.csv"),
 			func(record map[string]string) bool {
 				return record["currency"] != "" && record["locale"] != ""
@@ -474,29 +775,49 @@ func Cleanse() {
 			},
 		),
 	)
+
+	writeJsonAs("data/cleansed/country-locales.json", []CountryLocale{},
+		toObjects(readCsv("data/original/country-locales.csv"),
+			func(record map[string]string) bool {
+				return record["country"] != "" && record["locale"] != ""
+			},
+			func(record map[string]string) interface{} {
+				return CountryLocale{
+					CountryCode: strings.ToUpper(record["country"]),
+					LocaleId:    record["locale"],
+				}
+			},
+			func(record map[string]string) string {
+				return record["country"]
+			},
+		),
+	)
+
+	writeJsonAs("data/cleansed/language-locales.json", []LanguageLocale{},
+		toObjects(readCsv("data/original/language-locales.csv"),
+			func(record map[string]string) bool {
+				return record["language"] != "" && record["locale"] != ""
+			},
+			func(record map[string]string) interface{} {
+				return LanguageLocale{
+					LanguageCode: record["language"],
+					LocaleId:     record["locale"],
+				}
+			},
+			func(record map[string]string) string {
+				return record["language"]
+			},
+		),
+	)
 }
 
 func provinceType(value string) string {
 	finalValue := common.FormatUnderscore(strings.ToLower(value))
 
-	validValues := []string{
-		"city",
-		"dependency",
-		"district",
-		"emirate",
-		"entity",
-		"municipality",
-		"outlying_area",
-		"parish",
-		"province",
-		"state",
-		"territory",
-	}
-
-	if common.ContainsIgnoreCase(validValues, finalValue) {
+	if common.ContainsIgnoreCase(common.ProvinceTypes, finalValue) {
 		return finalValue
 	} else {
-		return "other"
+		return common.ProvinceTypeOther
 	}
 }
 
@@ -531,11 +852,33 @@ func countryName(record map[string]string) string {
 	}
 }
 
+// countryIdentifier Returns the trimmed value of a column in the source
+// countries file, which uses blank values (including non-breaking spaces)
+// when an identifier does not exist
+func countryIdentifier(record map[string]string, column string) string {
+	return strings.TrimSpace(record[column])
+}
+
+func geonameId(record map[string]string) int {
+	value := countryIdentifier(record, "Geoname ID")
+	if value == "" {
+		return 0
+	}
+	return toInt32(value)
+}
+
 func writeJson(target string, objects interface{}) {
 	fmt.Printf("Writing %s\n", target)
 	common.WriteJson(target, objects)
 }
 
+// writeJsonAs Writes objects described by the type of 'as', e.g. the
+// []interface{} returned by toObjects
+func writeJsonAs(target string, as interface{}, objects interface{}) {
+	fmt.Printf("Writing %s\n", target)
+	common.WriteJsonAs(target, as, objects)
+}
+
 // readCsv Reads a CSV file, returning a list of map[string]string objects
 func readCsvWithHeaders(file string, headers []string) []map[string]string {
 	input, err := os.Open(file)
@@ -595,7 +938,76 @@ func toMap(headers []string, record []string) map[string]string {
 	return row
 }
 
-func readLanguages(file string) ([]Language, []LocaleName) {
+// readLanguageTagAliases Reads the CLDR replacements for single deprecated
+// subtags. Aliases of subtag sequences (e.g. 'sgn_BR') are skipped
+func readLanguageTagAliases(file string) LanguageTagAliases {
+	data := CldrAliases{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr aliases: %s", err))
+
+	alias := data.Supplemental.Metadata.Alias
+	return LanguageTagAliases{
+		Languages: toSubtagAliases(alias.LanguageAlias),
+		Scripts:   toSubtagAliases(alias.ScriptAlias),
+		Regions:   toSubtagAliases(alias.TerritoryAlias),
+		Variants:  toSubtagAliases(alias.VariantAlias),
+	}
+}
+
+func toSubtagAliases(aliases map[string]CldrAliasReplacement) map[string]string {
+	all := map[string]string{}
+	for subtag, a := range aliases {
+		if strings.Contains(subtag, "_") || a.Replacement == "" {
+			continue
+		}
+		all[subtag] = strings.Replace(a.Replacement, "_", "-", -1)
+	}
+	return all
+}
+
+// readParentLocales Reads the CLDR locales whose parent is not found by
+// truncation, e.g. 'en-IN' => 'en-001' and 'zh-Hant' => 'root'
+func readParentLocales(file string) map[string]string {
+	data := CldrParentLocales{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr parent locales: %s", err))
+
+	parents := map[string]string{}
+	for locale, parent := range data.Supplemental.ParentLocales.ParentLocale {
+		parents[common.FormatLocaleId(locale)] = common.FormatLocaleId(parent)
+	}
+	return parents
+}
+
+// readLikelySubtags Reads the CLDR likely subtags as BCP 47 language tags,
+// e.g. 'zh-TW' => 'zh-Hant-TW'
+func readLikelySubtags(file string) map[string]string {
+	data := CldrLikelySubtags{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr likely subtags: %s", err))
+
+	likelySubtags := map[string]string{}
+	for tag, likely := range data.Supplemental.LikelySubtags {
+		likelySubtags[common.FormatLocaleId(tag)] = common.FormatLocaleId(likely)
+	}
+	return likelySubtags
+}
+
+// languageScript Returns the ISO 15924 code of the script the language is
+// most likely written in, e.g. "Latn" for "de" (de-Latn-DE)
+func languageScript(likelySubtags map[string]string, code string) string {
+	tag, ok := likelySubtags[code]
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(tag, "-")
+	if len(parts) < 2 || len(parts[1]) != 4 {
+		return ""
+	}
+	return parts[1]
+}
+
+func readLanguages(file string, likelySubtags map[string]string) ([]Language, []LocaleName) {
 	lang := IncomingLanguages{}
 	err := json.Unmarshal(common.ReadFile(file), &lang)
 	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall languages: %s", err))
@@ -606,7 +1018,7 @@ func readLanguages(file string) ([]Language, []LocaleName) {
 
 	for _, l := range lang.Languages {
 		name := l.Names[0]
-		if len(l.Iso_639_2) > 0 && name != "" && len(l.Countries) > 0 {
+		if len(l.Iso_639_1) > 0 && name != "" && len(l.Countries) > 0 {
 			locales := []string{}
 			for _, incomingLocale := range l.Locales {
 				localeId := common.FormatLocaleId(incomingLocale.Id)
@@ -617,11 +1029,25 @@ func readLanguages(file string) ([]Language, []LocaleName) {
 				locales = append(locales, localeId)
 			}
 
+			// Prefer the direction of the language's CLDR script; the
+			// source lists e.g. Kurdish as RTL though it is most
+			// commonly written in Latin script
+			script := languageScript(likelySubtags, l.Iso_639_1)
+			direction := common.ScriptDirection(script)
+			if script == "" {
+				direction = strings.ToLower(l.Direction)
+			}
+
 			languages = append(languages, Language{
-				Name:      name,
-				Iso_639_2: l.Iso_639_2,
-				Countries: l.Countries,
-				Locales:   locales,
+				Name:       name,
+				Iso_639_1:  l.Iso_639_1,
+				Iso_639_2B: l.Iso_639_2B,
+				Iso_639_2T: l.Iso_639_2T,
+				Iso_639_3:  l.Iso_639_3,
+				Script:     script,
+				Direction:  direction,
+				Countries:  l.Countries,
+				Locales:    locales,
 			})
 		}
 	}
@@ -636,6 +1062,9 @@ func readLanguages(file string) ([]Language, []LocaleName) {
 	return languages, names
 }
 
+// readCurrencySymbols Reads the default symbols of each currency from the
+// CLDR currencies of international English ('en-001'), where the US
+// dollar is 'US$' rather than the '$' of American English
 func readCurrencySymbols(file string) map[string]CurrencySymbols {
 	data := CldrCurrencies{}
 	err := json.Unmarshal(common.ReadFile(file), &data)
@@ -657,15 +1086,8 @@ func readCurrencySymbols(file string) map[string]CurrencySymbols {
 				narrow = c.SymbolAltNarrow
 			}
 
-			var primary string
-			if code == "USD" {
-				primary = "US$"
-			} else {
-				primary = c.Symbol
-			}
-
 			currencySymbols[code] = CurrencySymbols{
-				Primary: primary,
+				Primary: c.Symbol,
 				Narrow:  narrow,
 			}
 		}
@@ -673,6 +1095,293 @@ func readCurrencySymbols(file string) map[string]CurrencySymbols {
 	return currencySymbols
 }
 
+func readWindowsZones(file string) []WindowsZone {
+	data := CldrWindowsZones{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr windows zones: %s", err))
+
+	windowsZones := []WindowsZone{}
+	for _, m := range data.Supplemental.WindowsZones.MapTimezones {
+		z := m.MapZone
+		if z.Other == "" || z.Type == "" {
+			fmt.Printf("Invalid windows zone mapping: %+v\n", z)
+			os.Exit(1)
+		}
+
+		windowsZones = append(windowsZones, WindowsZone{
+			WindowsId: z.Other,
+			Territory: z.Territory,
+			Timezones: strings.Fields(z.Type),
+		})
+	}
+
+	return windowsZones
+}
+
+// The key in CLDR currency fractions used for currencies not otherwise listed
+const DefaultCurrencyFraction = "DEFAULT"
+
+// readCurrencyFractions Reads CLDR digits and rounding increments for each
+// currency, keyed by currency code. Cash digits and rounding default to
+// the regular values when CLDR does not list them.
+func readCurrencyFractions(file string) map[string]CurrencyFraction {
+	data := CldrCurrencyData{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr currency data: %s", err))
+
+	fractions := map[string]CurrencyFraction{}
+	for code, f := range data.Supplemental.CurrencyData.Fractions {
+		fraction := CurrencyFraction{
+			Digits:   toInt32(f.Digits),
+			Rounding: toInt32(f.Rounding),
+		}
+		fraction.CashDigits = fraction.Digits
+		if f.CashDigits != "" {
+			fraction.CashDigits = toInt32(f.CashDigits)
+		}
+		fraction.CashRounding = fraction.Rounding
+		if f.CashRounding != "" {
+			fraction.CashRounding = toInt32(f.CashRounding)
+		}
+		fractions[code] = fraction
+	}
+
+	if _, ok := fractions[DefaultCurrencyFraction]; !ok {
+		fmt.Printf("CLDR currency data is missing %s fractions\n", DefaultCurrencyFraction)
+		os.Exit(1)
+	}
+	return fractions
+}
+
+// readCountryCurrencies Reads the currencies each country has used over
+// time, in CLDR order (most recent first). 'alpha3' maps ISO 3166-1 alpha-2
+// codes to alpha-3; territories we do not know (e.g. 'SU') are skipped.
+func readCountryCurrencies(file string, alpha3 map[string]string) []CountryCurrency {
+	data := CldrCurrencyData{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr currency data: %s", err))
+
+	regions := []string{}
+	for region := range data.Supplemental.CurrencyData.Region {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	all := []CountryCurrency{}
+	for _, region := range regions {
+		countryCode := alpha3[region]
+		if countryCode == "" {
+			continue
+		}
+		for _, entry := range data.Supplemental.CurrencyData.Region[region] {
+			for code, tenure := range entry {
+				all = append(all, CountryCurrency{
+					CountryCode:  countryCode,
+					CurrencyCode: code,
+					From:         toCurrencyDate(tenure.From),
+					To:           toCurrencyDate(tenure.To),
+					Tender:       tenure.Tender != "false",
+				})
+			}
+		}
+	}
+	return all
+}
+
+// readCountryLanguages Reads the share of each country's population that
+// speaks each of our languages, and its official status, from CLDR
+// territoryInfo. CLDR lists some languages by script (e.g. 'zh_Hant' in
+// Taiwan); we keep the largest share of each language.
+func readCountryLanguages(file string, alpha3 map[string]string, languages []Language) []CountryLanguage {
+	data := CldrTerritoryInfo{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr territory info: %s", err))
+
+	codes := []string{}
+	for _, l := range languages {
+		codes = append(codes, l.Iso_639_1)
+	}
+
+	regions := []string{}
+	for region := range data.Supplemental.TerritoryInfo {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	all := []CountryLanguage{}
+	for _, region := range regions {
+		countryCode := alpha3[region]
+		if countryCode == "" {
+			continue
+		}
+
+		byLanguage := map[string]CountryLanguage{}
+		for code, lp := range data.Supplemental.TerritoryInfo[region].LanguagePopulation {
+			languageCode := localeLanguage(common.FormatLocaleId(code))
+			if !common.Contains(codes, languageCode) {
+				continue
+			}
+			percent, err := strconv.ParseFloat(lp.PopulationPercent, 64)
+			util.ExitIfError(err, fmt.Sprintf("Invalid population percent[%s] for language[%s] in territory[%s]", lp.PopulationPercent, code, region))
+
+			existing, ok := byLanguage[languageCode]
+			if !ok || percent > existing.PopulationPercent {
+				byLanguage[languageCode] = CountryLanguage{
+					CountryCode:       countryCode,
+					LanguageCode:      languageCode,
+					PopulationPercent: percent,
+					OfficialStatus:    lp.OfficialStatus,
+				}
+			}
+		}
+
+		countryLanguages := []CountryLanguage{}
+		for _, cl := range byLanguage {
+			countryLanguages = append(countryLanguages, cl)
+		}
+		slice.Sort(countryLanguages[:], func(i, j int) bool {
+			if countryLanguages[i].PopulationPercent != countryLanguages[j].PopulationPercent {
+				return countryLanguages[i].PopulationPercent > countryLanguages[j].PopulationPercent
+			}
+			return countryLanguages[i].LanguageCode < countryLanguages[j].LanguageCode
+		})
+		all = append(all, countryLanguages...)
+	}
+	return all
+}
+
+// readCountryPopulations Reads each country's population from CLDR
+// territoryInfo, keyed by ISO 3166-1 alpha-3 code
+func readCountryPopulations(file string, alpha3 map[string]string) map[string]int64 {
+	data := CldrTerritoryInfo{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr territory info: %s", err))
+
+	populations := map[string]int64{}
+	for region, t := range data.Supplemental.TerritoryInfo {
+		countryCode := alpha3[region]
+		if countryCode == "" || t.Population == "" {
+			continue
+		}
+		population, err := strconv.ParseInt(t.Population, 10, 64)
+		util.ExitIfError(err, fmt.Sprintf("Invalid population[%s] for territory[%s]", t.Population, region))
+		populations[countryCode] = population
+	}
+	return populations
+}
+
+// toCurrencyDate Validates a CLDR currency tenure date (e.g. '1993-06-25')
+func toCurrencyDate(value string) string {
+	if value == "" {
+		return ""
+	}
+	_, err := time.Parse(common.CurrencyDateFormat, value)
+	util.ExitIfError(err, fmt.Sprintf("Invalid currency date[%s]: %s", value, err))
+	return value
+}
+
+// readHistoricCurrencies Returns the currencies that countries have used,
+// but which are missing from our currency list, named from CLDR. They are
+// kept apart from our currency list, which lists the currencies we support
+func readHistoricCurrencies(currencies []Currency, countryCurrencies []CountryCurrency, cldrFile string, fractions map[string]CurrencyFraction) []Currency {
+	data := CldrCurrencies{}
+	err := json.Unmarshal(common.ReadFile(cldrFile), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr currencies: %s", err))
+
+	names := map[string]string{}
+	for _, main := range data.Main {
+		for code, c := range main.Numbers.Currencies {
+			names[code] = c.Name
+		}
+	}
+
+	known := map[string]bool{}
+	for _, c := range currencies {
+		known[c.Iso_4217_3] = true
+	}
+
+	historic := []Currency{}
+	for _, cc := range countryCurrencies {
+		if known[cc.CurrencyCode] {
+			continue
+		}
+		name := names[cc.CurrencyCode]
+		if name == "" {
+			fmt.Printf("Currency %s has no name in CLDR\n", cc.CurrencyCode)
+			os.Exit(1)
+		}
+		fraction, ok := fractions[cc.CurrencyCode]
+		if !ok {
+			fraction = fractions[DefaultCurrencyFraction]
+		}
+
+		historic = append(historic, Currency{
+			Name:           name,
+			Iso_4217_3:     cc.CurrencyCode,
+			NumberDecimals: fraction.Digits,
+		})
+		known[cc.CurrencyCode] = true
+	}
+
+	return sortCurrencies(historic)
+}
+
+func readCurrencyRemappings(file string) []CurrencyRemapping {
+	all := []CurrencyRemapping{}
+	for _, record := range readCsv(file) {
+		if record["profile"] == "" || record["currency"] == "" || record["target"] == "" {
+			fmt.Printf("Invalid currency remapping: %s\n", record)
+			os.Exit(1)
+		}
+		if record["rationale"] == "" {
+			fmt.Printf("Currency remapping for %s in profile %s is missing a rationale\n", record["currency"], record["profile"])
+			os.Exit(1)
+		}
+		all = append(all, CurrencyRemapping{
+			Profile:        record["profile"],
+			CurrencyCode:   strings.ToUpper(record["currency"]),
+			TargetCurrency: strings.ToUpper(record["target"]),
+			Rationale:      record["rationale"],
+		})
+	}
+
+	slice.Sort(all[:], func(i, j int) bool {
+		if all[i].Profile != all[j].Profile {
+			return all[i].Profile < all[j].Profile
+		}
+		return all[i].CurrencyCode < all[j].CurrencyCode
+	})
+
+	for i := 1; i < len(all); i++ {
+		if all[i].Profile == all[i-1].Profile && all[i].CurrencyCode == all[i-1].CurrencyCode {
+			fmt.Printf("Currency remapping for %s in profile %s is listed twice\n", all[i].CurrencyCode, all[i].Profile)
+			os.Exit(1)
+		}
+	}
+	return all
+}
+
+// ToCurrencyRemappingProfiles Groups the remappings, sorted by profile, into
+// their profiles
+func ToCurrencyRemappingProfiles(remappings []CurrencyRemapping) []common.CurrencyRemappingProfile {
+	all := []common.CurrencyRemappingProfile{}
+	for _, r := range remappings {
+		if len(all) == 0 || all[len(all)-1].Profile != r.Profile {
+			all = append(all, common.CurrencyRemappingProfile{
+				Profile: r.Profile,
+				Rules:   []common.CurrencyRemapping{},
+			})
+		}
+		profile := &all[len(all)-1]
+		profile.Rules = append(profile.Rules, common.CurrencyRemapping{
+			Currency:  r.CurrencyCode,
+			Target:    r.TargetCurrency,
+			Rationale: r.Rationale,
+		})
+	}
+	return all
+}
+
 func readCurrencies(file string) []Currency {
 	data := []IncomingCurrency{}
 	err := json.Unmarshal(common.ReadFile(file), &data)
@@ -707,10 +1416,14 @@ func readNumbers(file string) []Number {
 		numbers = append(numbers, Number{
 			Language: main.Identity.Language,
 			Country:  country,
+			Script:   main.Identity.Script,
+			Region:   main.Identity.Territory,
+			Variant:  main.Identity.Variant,
 			Separators: Separators{
 				Decimal: main.Numbers.Symbols.Decimal,
 				Group:   main.Numbers.Symbols.Group,
 			},
+			CurrencyFormat: main.Numbers.CurrencyFormats.Standard,
 		})
 	}
 
@@ -766,6 +1479,13 @@ func LoadCountryDuties() []CountryDuty {
 	return countryDuties
 }
 
+func LoadCountryTelephones() []CountryTelephone {
+	countryTelephones := []CountryTelephone{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/country-telephones.json"), &countryTelephones)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal country telephones: %s", err))
+	return countryTelephones
+}
+
 func LoadCountryContinents() []CountryContinent {
 	countryContinents := []CountryContinent{}
 	err := json.Unmarshal(common.ReadFile("data/cleansed/country-continents.json"), &countryContinents)
@@ -792,6 +1512,37 @@ func LoadCurrencyLocales() map[string]string {
 	return table
 }
 
+func LoadCountryLocales() map[string]string {
+	countryLocales := []CountryLocale{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/country-locales.json"), &countryLocales)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal country locales: %s", err))
+
+	table := map[string]string{}
+	for _, cl := range countryLocales {
+		table[cl.CountryCode] = cl.LocaleId
+	}
+	return table
+}
+
+func LoadLanguageLocales() map[string]string {
+	languageLocales := []LanguageLocale{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/language-locales.json"), &languageLocales)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal language locales: %s", err))
+
+	table := map[string]string{}
+	for _, ll := range languageLocales {
+		table[ll.LanguageCode] = ll.LocaleId
+	}
+	return table
+}
+
+func LoadCountryPopulations() map[string]int64 {
+	populations := map[string]int64{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/country-populations.json"), &populations)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal country populations: %s", err))
+	return populations
+}
+
 // For some reason, some countries are missing from the underlying CLDR "Numbers" data that we ingest.
 // It's easier to add this manual override than figure out how to fix the source data.
 func LoadLocaleOverrides() []common.Locale {
@@ -867,6 +1618,34 @@ func LoadCurrencies() []Currency {
 	return currencies
 }
 
+func LoadHistoricCurrencies() []Currency {
+	currencies := []Currency{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/historic-currencies.json"), &currencies)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal historic currencies: %s", err))
+	return currencies
+}
+
+func LoadCurrencyRemappings() []CurrencyRemapping {
+	remappings := []CurrencyRemapping{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/currency-remappings.json"), &remappings)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal currency remappings: %s", err))
+	return remappings
+}
+
+func LoadCountryLanguages() []CountryLanguage {
+	countryLanguages := []CountryLanguage{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/country-languages.json"), &countryLanguages)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal country languages: %s", err))
+	return countryLanguages
+}
+
+func LoadCountryCurrencies() []CountryCurrency {
+	countryCurrencies := []CountryCurrency{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/country-currencies.json"), &countryCurrencies)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal country currencies: %s", err))
+	return countryCurrencies
+}
+
 func LoadCurrencySymbols() map[string]CurrencySymbols {
 	symbols := map[string]CurrencySymbols{}
 	err := json.Unmarshal(common.ReadFile("data/cleansed/currency-symbols.json"), &symbols)
@@ -874,6 +1653,13 @@ func LoadCurrencySymbols() map[string]CurrencySymbols {
 	return symbols
 }
 
+func LoadCurrencyFractions() map[string]CurrencyFraction {
+	fractions := map[string]CurrencyFraction{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/currency-fractions.json"), &fractions)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal currency fractions: %s", err))
+	return fractions
+}
+
 func LoadLanguages() []Language {
 	languages := []Language{}
 	err := json.Unmarshal(common.ReadFile("data/cleansed/languages.json"), &languages)
@@ -888,6 +1674,27 @@ func LoadLocaleNames() []LocaleName {
 	return names
 }
 
+func LoadParentLocales() map[string]string {
+	parents := map[string]string{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/parent-locales.json"), &parents)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal parent locales: %s", err))
+	return parents
+}
+
+func LoadLikelySubtags() map[string]string {
+	likelySubtags := map[string]string{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/likely-subtags.json"), &likelySubtags)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal likely subtags: %s", err))
+	return likelySubtags
+}
+
+func LoadLanguageTagAliases() LanguageTagAliases {
+	aliases := LanguageTagAliases{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/language-tag-aliases.json"), &aliases)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal language tag aliases: %s", err))
+	return aliases
+}
+
 func LoadNumbers() []Number {
 	numbers := []Number{}
 	err := json.Unmarshal(common.ReadFile("data/cleansed/numbers.json"), &numbers)
@@ -906,6 +1713,27 @@ func loadTimezonesFromPath(path string) []Timezone {
 	return timezones
 }
 
+func LoadTimezoneMetazones() []TimezoneMetazone {
+	metazones := []TimezoneMetazone{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/timezone-metazones.json"), &metazones)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal timezone metazones: %s", err))
+	return metazones
+}
+
+func LoadTimezoneNames() []TimezoneNames {
+	names := []TimezoneNames{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/timezone-names.json"), &names)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal timezone names: %s", err))
+	return names
+}
+
+func LoadWindowsZones() []WindowsZone {
+	windowsZones := []WindowsZone{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/windows-zones.json"), &windowsZones)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal windows zones: %s", err))
+	return windowsZones
+}
+
 func LoadCountryTimezones() []CountryTimezone {
 	countryTimezones := []CountryTimezone{}
 	err := json.Unmarshal(common.ReadFile("data/cleansed/country-timezones.json"), &countryTimezones)
@@ -0,0 +1,92 @@
+package cleanse
+
+// Reads the currency symbols used in each CLDR locale, e.g. '$' for USD
+// in 'en', 'US$' in 'en-CA' and '$US' in 'fr'.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bradfitz/slice"
+	"github.com/flowcommerce/json-reference/common"
+	"github.com/flowcommerce/tools/util"
+)
+
+type LocaleCurrencySymbols struct {
+	Locale  string                     `json:"locale"`
+	Symbols map[string]CurrencySymbols `json:"symbols"`
+}
+
+// loadCldrLocaleCurrencySymbols Reads currencies.json for the root locale
+// and each CLDR locale in a language we know of, keeping the symbols of
+// our currencies. The narrow symbol is omitted when it is the same as the
+// primary one.
+func loadCldrLocaleCurrencySymbols(dir string, languages []Language, currencies []Currency) []LocaleCurrencySymbols {
+	codes := []string{}
+	for _, l := range languages {
+		codes = append(codes, l.Iso_639_1)
+	}
+	currencyCodes := []string{}
+	for _, c := range currencies {
+		currencyCodes = append(currencyCodes, c.Iso_4217_3)
+	}
+
+	all := []LocaleCurrencySymbols{}
+	filepath.Walk(dir, func(path string, dirInfo os.FileInfo, err error) error {
+		if dirInfo == nil || !dirInfo.IsDir() || path == dir {
+			return nil
+		}
+		locale := dirInfo.Name()
+		if locale != common.RootLocale && !common.Contains(codes, localeLanguage(locale)) {
+			return filepath.SkipDir
+		}
+
+		currenciesPath := fmt.Sprintf("%s/%s/currencies.json", dir, locale)
+		if !fileExists(currenciesPath) {
+			return filepath.SkipDir
+		}
+
+		symbols := map[string]CurrencySymbols{}
+		for code, c := range readCldrLocaleCurrencies(currenciesPath, locale) {
+			if c.Symbol == "" || !common.Contains(currencyCodes, code) {
+				continue
+			}
+			narrow := c.SymbolAltNarrow
+			if narrow == c.Symbol {
+				narrow = ""
+			}
+			symbols[code] = CurrencySymbols{
+				Primary: c.Symbol,
+				Narrow:  narrow,
+			}
+		}
+		if len(symbols) > 0 {
+			all = append(all, LocaleCurrencySymbols{
+				Locale:  locale,
+				Symbols: symbols,
+			})
+		}
+		return filepath.SkipDir
+	})
+
+	slice.Sort(all[:], func(i, j int) bool {
+		return all[i].Locale < all[j].Locale
+	})
+	return all
+}
+
+func readCldrLocaleCurrencies(file string, locale string) map[string]CldrIncomingCurrency {
+	data := CldrCurrencies{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr currencies %s: %s", file, err))
+	return data.Main[locale].Numbers.Currencies
+}
+
+func LoadLocaleCurrencySymbols() []LocaleCurrencySymbols {
+	symbols := []LocaleCurrencySymbols{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/locale-currency-symbols.json"), &symbols)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal locale currency symbols: %s", err))
+	return symbols
+}
@@ -0,0 +1,155 @@
+package cleanse
+
+// Reads the names of languages and territories in each CLDR locale, used to
+// build language autonyms and localized language and locale names.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bradfitz/slice"
+	"github.com/flowcommerce/json-reference/common"
+	"github.com/flowcommerce/tools/util"
+)
+
+type LocaleDisplayNames struct {
+	Locale          string            `json:"locale"`
+	LocalePattern   string            `json:"locale_pattern"`
+	LocaleSeparator string            `json:"locale_separator"`
+	Languages       map[string]string `json:"languages"`
+	Scripts         map[string]string `json:"scripts"`
+	Territories     map[string]string `json:"territories"`
+}
+
+type CldrLocaleDisplayNamesFile struct {
+	Main map[string]CldrLocaleDisplayNamesMain `json:"main"`
+}
+
+type CldrLocaleDisplayNamesMain struct {
+	LocaleDisplayNames CldrLocaleDisplayNames `json:"localeDisplayNames"`
+}
+
+type CldrLocaleDisplayNames struct {
+	Languages            map[string]string        `json:"languages"`
+	Scripts              map[string]string        `json:"scripts"`
+	Territories          map[string]string        `json:"territories"`
+	LocaleDisplayPattern CldrLocaleDisplayPattern `json:"localeDisplayPattern"`
+}
+
+type CldrLocaleDisplayPattern struct {
+	LocalePattern   string `json:"localePattern"`
+	LocaleSeparator string `json:"localeSeparator"`
+}
+
+// loadCldrLocaleDisplayNames Reads languages.json, scripts.json,
+// territories.json and localeDisplayNames.json for each CLDR locale in a
+// language we know of. Only the names of those languages, of the scripts
+// qualifying CLDR locales, and of our countries (by ISO 3166-1 alpha-2
+// code) and UN M49 regions are kept.
+func loadCldrLocaleDisplayNames(dir string, languages []Language, alpha3 map[string]string) []LocaleDisplayNames {
+	codes := []string{}
+	for _, l := range languages {
+		codes = append(codes, l.Iso_639_1)
+	}
+
+	scripts := []string{}
+	filepath.Walk(dir, func(path string, dirInfo os.FileInfo, err error) error {
+		if dirInfo == nil || !dirInfo.IsDir() || path == dir {
+			return nil
+		}
+		if tag, err := common.ParseLanguageTag(dirInfo.Name()); err == nil && tag.Script != "" && !common.Contains(scripts, tag.Script) {
+			scripts = append(scripts, tag.Script)
+		}
+		return filepath.SkipDir
+	})
+
+	all := []LocaleDisplayNames{}
+	filepath.Walk(dir, func(path string, dirInfo os.FileInfo, err error) error {
+		if dirInfo == nil || !dirInfo.IsDir() || path == dir {
+			return nil
+		}
+		locale := dirInfo.Name()
+		if !common.Contains(codes, localeLanguage(locale)) {
+			return filepath.SkipDir
+		}
+
+		names := LocaleDisplayNames{
+			Locale:      locale,
+			Languages:   map[string]string{},
+			Scripts:     map[string]string{},
+			Territories: map[string]string{},
+		}
+		for _, file := range []string{"languages.json", "scripts.json", "territories.json", "localeDisplayNames.json"} {
+			namesPath := fmt.Sprintf("%s/%s/%s", dir, locale, file)
+			if !fileExists(namesPath) {
+				continue
+			}
+			display := readCldrLocaleDisplayNames(namesPath, locale)
+			for code, name := range display.Languages {
+				if !strings.Contains(code, "-alt-") && common.Contains(codes, localeLanguage(code)) {
+					names.Languages[code] = name
+				}
+			}
+			for code, name := range display.Scripts {
+				if common.Contains(scripts, code) {
+					names.Scripts[code] = name
+				}
+			}
+			for code, name := range display.Territories {
+				if _, ok := alpha3[code]; ok || isM49Region(code) {
+					names.Territories[code] = name
+				}
+			}
+			if display.LocaleDisplayPattern.LocalePattern != "" {
+				names.LocalePattern = display.LocaleDisplayPattern.LocalePattern
+				names.LocaleSeparator = display.LocaleDisplayPattern.LocaleSeparator
+			}
+		}
+		if len(names.Languages) > 0 {
+			all = append(all, names)
+		}
+		return filepath.SkipDir
+	})
+
+	slice.Sort(all[:], func(i, j int) bool {
+		return all[i].Locale < all[j].Locale
+	})
+	return all
+}
+
+func readCldrLocaleDisplayNames(file string, locale string) CldrLocaleDisplayNames {
+	data := CldrLocaleDisplayNamesFile{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr locale display names %s: %s", file, err))
+	return data.Main[locale].LocaleDisplayNames
+}
+
+// isM49Region Returns true for UN M49 area codes used as regions in
+// locales, e.g. '419' (Latin America)
+func isM49Region(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// localeLanguage Returns the language subtag of a CLDR locale, e.g. "zh"
+// for "zh-Hant-TW"
+func localeLanguage(locale string) string {
+	return strings.SplitN(locale, "-", 2)[0]
+}
+
+func LoadLocaleDisplayNames() []LocaleDisplayNames {
+	names := []LocaleDisplayNames{}
+	err := json.Unmarshal(common.ReadFile("data/cleansed/locale-display-names.json"), &names)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal locale display names: %s", err))
+	return names
+}
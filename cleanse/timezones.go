@@ -0,0 +1,686 @@
+package cleanse
+
+// Builds the timezone dataset from a compiled IANA tzdata (zoneinfo)
+// install, or from a zip archive of one.
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/slice"
+	"github.com/flowcommerce/json-reference/common"
+	"github.com/flowcommerce/tools/util"
+)
+
+type TimezoneTransition struct {
+	At           time.Time `json:"at"`
+	Offset       int       `json:"offset"`
+	Abbreviation string    `json:"abbreviation"`
+}
+
+// Locations searched for tzdata, in order. The ZONEINFO environment
+// variable, if set, takes precedence.
+var zoneinfoPaths = []string{
+	"data/source/zoneinfo.zip",
+	"/usr/share/zoneinfo",
+}
+
+type tzdataSource interface {
+	ReadFile(name string) ([]byte, error)
+}
+
+type tzdataDirectory struct {
+	dir string
+}
+
+func (d tzdataDirectory) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(d.dir, filepath.FromSlash(name)))
+}
+
+type tzdataArchive struct {
+	files map[string]*zip.File
+}
+
+func (a tzdataArchive) ReadFile(name string) ([]byte, error) {
+	f := a.files[name]
+	if f == nil {
+		return nil, fmt.Errorf("File %s not found in tzdata archive", name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func zoneinfoPath() string {
+	if path := os.Getenv("ZONEINFO"); path != "" {
+		return path
+	}
+	for _, path := range zoneinfoPaths {
+		if fileExists(path) {
+			return path
+		}
+	}
+	fmt.Printf("ERROR: Could not find tzdata. Set ZONEINFO to a zoneinfo directory or archive\n")
+	os.Exit(1)
+	return ""
+}
+
+func openTzdata(path string) tzdataSource {
+	if strings.HasSuffix(path, ".zip") {
+		r, err := zip.OpenReader(path)
+		util.ExitIfError(err, fmt.Sprintf("Error opening tzdata archive %s: %s", path, err))
+
+		files := map[string]*zip.File{}
+		for _, f := range r.File {
+			// Archives are often created from the parent directory, e.g. 'zoneinfo/Europe/Paris'
+			name := f.Name
+			if i := strings.Index(name, "zoneinfo/"); i >= 0 {
+				name = name[i+len("zoneinfo/"):]
+			}
+			files[name] = f
+		}
+		return tzdataArchive{files: files}
+	}
+	return tzdataDirectory{dir: path}
+}
+
+// readTzdataZones Reads the release, canonical zone names and links from
+// the 'tzdata.zi' file that ships with every zoneinfo install. Returns the
+// version (e.g. '2024a'), the sorted list of zones and a map from link
+// name to its target zone.
+func readTzdataZones(source tzdataSource) (string, []string, map[string]string) {
+	contents, err := source.ReadFile("tzdata.zi")
+	util.ExitIfError(err, fmt.Sprintf("Error reading tzdata.zi: %s", err))
+
+	version := ""
+	zones := []string{}
+	links := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "#" && fields[1] == "version" {
+			version = fields[2]
+		} else if len(fields) >= 2 && fields[0] == "Z" {
+			zones = append(zones, fields[1])
+		} else if len(fields) >= 3 && fields[0] == "L" {
+			links[fields[2]] = fields[1]
+		}
+	}
+	util.ExitIfError(scanner.Err(), fmt.Sprintf("Error reading tzdata.zi: %s", scanner.Err()))
+
+	if version == "" {
+		fmt.Printf("ERROR: tzdata.zi does not declare its version\n")
+		os.Exit(1)
+	}
+
+	sort.Strings(zones)
+	return version, zones, links
+}
+
+// tzdataYear The year in which zones are inspected: the year of the
+// tzdata release (e.g. 2024 for '2024a'), so that the output only changes
+// with the tzdata
+func tzdataYear(version string) int {
+	year, err := strconv.Atoi(strings.TrimRight(version, "abcdefghijklmnopqrstuvwxyz"))
+	if err != nil {
+		fmt.Printf("ERROR: Invalid tzdata version[%s]\n", version)
+		os.Exit(1)
+	}
+	return year
+}
+
+// tzifRule Returns the POSIX TZ rule in the footer of a version 2+ TZif
+// file (e.g. 'CET-1CEST,M3.5.0,M10.5.0/3'), which governs the zone after
+// its last listed transition
+func tzifRule(data []byte) string {
+	if len(data) < 5 || data[4] < '2' || !bytes.HasSuffix(data, []byte("\n")) {
+		return ""
+	}
+	trimmed := data[:len(data)-1]
+	i := bytes.LastIndexByte(trimmed, '\n')
+	if i < 0 {
+		return ""
+	}
+	return string(trimmed[i+1:])
+}
+
+// The transitions and local time types of a TZif file, with its POSIX TZ
+// rule. Transitions are in seconds since the epoch, each with the index
+// of the type that takes effect.
+type tzif struct {
+	transitions []int64
+	indices     []byte
+	daylight    []bool // The isdst flag of each local time type
+	rule        string
+}
+
+// readTzif Reads the transitions of a TZif file, from the 64-bit data
+// block of version 2+ files as the 32-bit block may be empty
+func readTzif(data []byte) (tzif, error) {
+	const headerSize = 44
+
+	block := data
+	timeSize := 4
+	for {
+		if len(block) < headerSize || string(block[:4]) != "TZif" {
+			return tzif{}, fmt.Errorf("Invalid TZif header")
+		}
+		counts := make([]int, 6)
+		for i := range counts {
+			counts[i] = int(binary.BigEndian.Uint32(block[20+4*i:]))
+		}
+		isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt := counts[0], counts[1], counts[2], counts[3], counts[4], counts[5]
+		size := timecnt*timeSize + timecnt + typecnt*6 + charcnt + leapcnt*(timeSize+4) + isstdcnt + isutcnt
+		if len(block) < headerSize+size {
+			return tzif{}, fmt.Errorf("Truncated TZif data")
+		}
+
+		if timeSize == 4 && block[4] >= '2' {
+			block = block[headerSize+size:]
+			timeSize = 8
+			continue
+		}
+
+		z := tzif{rule: tzifRule(data)}
+		times := block[headerSize:]
+		for i := 0; i < timecnt; i++ {
+			if timeSize == 8 {
+				z.transitions = append(z.transitions, int64(binary.BigEndian.Uint64(times[i*8:])))
+			} else {
+				z.transitions = append(z.transitions, int64(int32(binary.BigEndian.Uint32(times[i*4:]))))
+			}
+		}
+		z.indices = times[timecnt*timeSize : timecnt*timeSize+timecnt]
+		types := times[timecnt*timeSize+timecnt:]
+		for i := 0; i < typecnt; i++ {
+			z.daylight = append(z.daylight, types[i*6+4] != 0)
+		}
+		for _, index := range z.indices {
+			if int(index) >= typecnt {
+				return tzif{}, fmt.Errorf("Invalid TZif type index")
+			}
+		}
+		return z, nil
+	}
+}
+
+// isDaylight Returns true if daylight saving time is observed at the
+// instant, where the zone's offset is 'offset' (in seconds). Instants
+// after the last transition are governed by the rule, under which any
+// offset other than the standard one is daylight saving time.
+func (z tzif) isDaylight(at time.Time, offset int) (bool, error) {
+	unix := at.Unix()
+	if z.rule != "" && (len(z.transitions) == 0 || unix >= z.transitions[len(z.transitions)-1]) {
+		standard, err := common.TimezoneRuleStandardOffset(z.rule)
+		if err != nil {
+			return false, err
+		}
+		return offset != standard, nil
+	}
+
+	i := sort.Search(len(z.transitions), func(i int) bool {
+		return z.transitions[i] > unix
+	})
+	if i == 0 {
+		// Before the first transition, the first type is in effect
+		return len(z.daylight) > 0 && z.daylight[0], nil
+	}
+	return z.daylight[z.indices[i-1]], nil
+}
+
+func readTimezones(path string, descriptions []Timezone) []Timezone {
+	source := openTzdata(path)
+	version, zones, links := readTzdataZones(source)
+	year := tzdataYear(version)
+
+	aliases := map[string][]string{}
+	for link, target := range links {
+		aliases[target] = append(aliases[target], link)
+	}
+
+	timezones := []Timezone{}
+	for _, name := range zones {
+		data, err := source.ReadFile(name)
+		util.ExitIfError(err, fmt.Sprintf("Error reading zoneinfo for %s: %s", name, err))
+
+		loc, err := time.LoadLocationFromTZData(name, data)
+		util.ExitIfError(err, fmt.Sprintf("Error parsing zoneinfo for %s: %s", name, err))
+
+		z, err := readTzif(data)
+		util.ExitIfError(err, fmt.Sprintf("Error parsing zoneinfo for %s: %s", name, err))
+
+		tz := toTimezone(loc, year, z)
+		tz.Rule = z.rule
+		tz.Description = timezoneDescription(descriptions, name, tz.Offset)
+
+		theseAliases := aliases[name]
+		sort.Strings(theseAliases)
+		tz.Aliases = theseAliases
+
+		timezones = append(timezones, tz)
+	}
+
+	return timezones
+}
+
+// toTimezone Inspects 'year' to find the standard and daylight offsets of
+// a zone along with its transitions in that year. Offsets are classified
+// using the isdst flag of the zone's local time types ('daylight', see
+// tzifDaylightTypes), so a zone with negative daylight saving time (e.g.
+// Africa/Casablanca during Ramadan, or Europe/Dublin in winter) has a
+// daylight offset lower than its standard one. When a zone uses more than
+// one standard or daylight offset in the year, the last one is kept.
+func toTimezone(loc *time.Location, year int, z tzif) Timezone {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	stateAt := func(at time.Time) zoneState {
+		abbreviation, offset := at.In(loc).Zone()
+		daylight, err := z.isDaylight(at, offset)
+		util.ExitIfError(err, fmt.Sprintf("Error reading zoneinfo for %s: %s", loc, err))
+		return zoneState{Abbreviation: abbreviation, Offset: offset, Daylight: daylight}
+	}
+
+	tz := Timezone{
+		Name: loc.String(),
+		Year: year,
+	}
+	observe := func(state zoneState) {
+		minutes := state.Offset / 60
+		if state.Daylight {
+			tz.DaylightOffset = &minutes
+			tz.DaylightAbbreviation = state.Abbreviation
+		} else {
+			tz.Offset = minutes
+			tz.Abbreviation = state.Abbreviation
+		}
+	}
+
+	state := stateAt(from)
+	observe(state)
+
+	transitions := []TimezoneTransition{}
+	previous := from
+	for t := from.Add(24 * time.Hour); !t.After(to); t = t.Add(24 * time.Hour) {
+		if next := stateAt(t); next.Offset != state.Offset || next.Daylight != state.Daylight {
+			at := findTransition(stateAt, previous, t)
+			if !at.Before(to) {
+				break
+			}
+			next = stateAt(at)
+			observe(next)
+			// Only changes of offset are listed, not e.g. a zone staying on
+			// its daylight offset permanently
+			if next.Offset != state.Offset {
+				transitions = append(transitions, TimezoneTransition{
+					At:           at,
+					Offset:       next.Offset / 60,
+					Abbreviation: next.Abbreviation,
+				})
+			}
+			state = next
+		}
+		previous = t
+	}
+	tz.Transitions = transitions
+
+	return tz
+}
+
+// The local time in effect in a zone at an instant, with its offset in
+// seconds
+type zoneState struct {
+	Abbreviation string
+	Offset       int
+	Daylight     bool
+}
+
+// findTransition Returns the first second at which the offset or
+// daylight saving time in effect at 'hi' takes effect, given that 'lo'
+// is still on the previous one
+func findTransition(stateAt func(time.Time) zoneState, lo time.Time, hi time.Time) time.Time {
+	before := stateAt(lo)
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if s := stateAt(mid); s.Offset == before.Offset && s.Daylight == before.Daylight {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// timezoneDescription Prefers the hand-maintained description for a zone,
+// falling back to its standard offset (e.g. '+05:30')
+func timezoneDescription(descriptions []Timezone, name string, offset int) string {
+	for _, d := range descriptions {
+		if d.Name == name && d.Description != "" {
+			return d.Description
+		}
+	}
+	return common.FormatOffset(offset)
+}
+
+// readCountryTimezones Maps countries to their zones using the tzdata
+// 'zone.tab' file, followed by any manual overrides. zone.tab lists each
+// zone of a country under the name used in that country (e.g.
+// 'America/Anguilla' rather than its link target 'America/Puerto_Rico'),
+// with the coordinates of its city, so names are kept as listed.
+// zone1970.tab is not used as its rows group countries sharing a zone
+// since 1970, so would add zones (and coordinates) from a city abroad.
+// 'alpha3' maps ISO 3166-1 alpha-2 codes to alpha-3.
+func readCountryTimezones(path string, alpha3 map[string]string, overrides []map[string]string) []CountryTimezone {
+	source := openTzdata(path)
+
+	all := []CountryTimezone{}
+	added := map[string]bool{}
+	add := func(ct CountryTimezone) {
+		key := ct.CountryCode + "/" + ct.TimezoneCode
+		if !added[key] {
+			all = append(all, ct)
+			added[key] = true
+		}
+	}
+
+	file := "zone.tab"
+	contents, err := source.ReadFile(file)
+	util.ExitIfError(err, fmt.Sprintf("Error reading %s: %s", file, err))
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			fmt.Printf("ERROR: Invalid line in %s: %s\n", file, line)
+			os.Exit(1)
+		}
+
+		countryCode := alpha3[fields[0]]
+		if countryCode == "" {
+			continue
+		}
+		var comments string
+		if len(fields) > 3 {
+			comments = fields[3]
+		}
+		add(CountryTimezone{
+			TimezoneCode: fields[2],
+			CountryCode:  countryCode,
+			Coordinates:  parseIso6709(fields[1]),
+			Comments:     comments,
+		})
+	}
+	util.ExitIfError(scanner.Err(), fmt.Sprintf("Error reading %s: %s", file, scanner.Err()))
+
+	for _, record := range overrides {
+		if record["country"] != "" && record["timezone"] != "" {
+			add(CountryTimezone{
+				TimezoneCode: record["timezone"],
+				CountryCode:  strings.ToUpper(record["country"]),
+				Comments:     record["comments"],
+			})
+		}
+	}
+
+	slice.Sort(all[:], func(i, j int) bool {
+		if all[i].CountryCode != all[j].CountryCode {
+			return all[i].CountryCode < all[j].CountryCode
+		}
+		return all[i].TimezoneCode < all[j].TimezoneCode
+	})
+
+	return all
+}
+
+// parseIso6709 Parses coordinates in the form used by zone.tab, either
+// ±DDMM±DDDMM or ±DDMMSS±DDDMMSS
+func parseIso6709(value string) *Coordinates {
+	split := strings.IndexAny(value[1:], "+-") + 1
+	if split <= 0 {
+		fmt.Printf("ERROR: Invalid coordinates[%s]\n", value)
+		os.Exit(1)
+	}
+	return &Coordinates{
+		Latitude:  parseIso6709Degrees(value[:split], 2),
+		Longitude: parseIso6709Degrees(value[split:], 3),
+	}
+}
+
+func parseIso6709Degrees(value string, degreeDigits int) float64 {
+	digits := value[1:]
+	degrees := float64(toInt32(digits[:degreeDigits]))
+	minutes := float64(toInt32(digits[degreeDigits : degreeDigits+2]))
+	var seconds float64
+	if len(digits) > degreeDigits+2 {
+		seconds = float64(toInt32(digits[degreeDigits+2:]))
+	}
+
+	result := degrees + minutes/60 + seconds/3600
+	if value[0] == '-' {
+		result = -result
+	}
+	return math.Round(result*10000) / 10000
+}
+
+type TimezoneMetazone struct {
+	Timezone string `json:"timezone"`
+	Metazone string `json:"metazone"`
+}
+
+type TimezoneNames struct {
+	Locale               string                   `json:"locale"`
+	HourFormat           string                   `json:"hour_format"`
+	GmtFormat            string                   `json:"gmt_format"`
+	GmtZeroFormat        string                   `json:"gmt_zero_format,omitempty"`
+	RegionFormat         string                   `json:"region_format,omitempty"`
+	RegionStandardFormat string                   `json:"region_standard_format,omitempty"`
+	RegionDaylightFormat string                   `json:"region_daylight_format,omitempty"`
+	Metazones            map[string]MetazoneNames `json:"metazones"`
+	Zones                map[string]MetazoneNames `json:"zones,omitempty"`
+	ExemplarCities       map[string]string        `json:"exemplar_cities"`
+}
+
+type MetazoneNames struct {
+	Generic  string `json:"generic,omitempty"`
+	Standard string `json:"standard,omitempty"`
+	Daylight string `json:"daylight,omitempty"`
+}
+
+type CldrMetaZones struct {
+	Supplemental CldrMetaZonesSupplemental `json:"supplemental"`
+}
+
+type CldrMetaZonesSupplemental struct {
+	MetaZones CldrMetaZonesMetaZones `json:"metaZones"`
+}
+
+type CldrMetaZonesMetaZones struct {
+	MetazoneInfo CldrMetazoneInfo `json:"metazoneInfo"`
+}
+
+type CldrMetazoneInfo struct {
+	Timezone map[string]json.RawMessage `json:"timezone"`
+}
+
+type CldrUsesMetazone struct {
+	UsesMetazone CldrMetazonePeriod `json:"usesMetazone"`
+}
+
+type CldrMetazonePeriod struct {
+	Metazone string `json:"_mzone"`
+	From     string `json:"_from,omitempty"`
+	To       string `json:"_to,omitempty"`
+}
+
+type CldrTimeZoneNamesFile struct {
+	Main map[string]CldrTimeZoneNamesMain `json:"main"`
+}
+
+type CldrTimeZoneNamesMain struct {
+	Identity CldrIdentity           `json:"identity"`
+	Dates    CldrTimeZoneNamesDates `json:"dates"`
+}
+
+type CldrTimeZoneNamesDates struct {
+	TimeZoneNames CldrTimeZoneNames `json:"timeZoneNames"`
+}
+
+type CldrTimeZoneNames struct {
+	HourFormat           string                           `json:"hourFormat"`
+	GmtFormat            string                           `json:"gmtFormat"`
+	GmtZeroFormat        string                           `json:"gmtZeroFormat"`
+	RegionFormat         string                           `json:"regionFormat"`
+	RegionStandardFormat string                           `json:"regionFormat-type-standard"`
+	RegionDaylightFormat string                           `json:"regionFormat-type-daylight"`
+	Zone                 map[string]json.RawMessage       `json:"zone"`
+	Metazone             map[string]CldrMetazoneLongNames `json:"metazone"`
+}
+
+type CldrMetazoneLongNames struct {
+	Long MetazoneNames `json:"long"`
+}
+
+// readMetazones Reads the metazone currently in use by each zone
+// (i.e. the period without an end date)
+func readMetazones(file string) []TimezoneMetazone {
+	data := CldrMetaZones{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr meta zones: %s", err))
+
+	all := []TimezoneMetazone{}
+	walkCldrZones("", data.Supplemental.MetaZones.MetazoneInfo.Timezone, func(name string, raw json.RawMessage) bool {
+		periods := []CldrUsesMetazone{}
+		if json.Unmarshal(raw, &periods) != nil {
+			return false
+		}
+		for _, p := range periods {
+			if p.UsesMetazone.To == "" {
+				all = append(all, TimezoneMetazone{
+					Timezone: name,
+					Metazone: p.UsesMetazone.Metazone,
+				})
+			}
+		}
+		return true
+	})
+
+	slice.Sort(all[:], func(i, j int) bool {
+		return all[i].Timezone < all[j].Timezone
+	})
+	return all
+}
+
+func loadCldrTimezoneNames(dir string) []TimezoneNames {
+	all := []TimezoneNames{}
+	filepath.Walk(dir, func(path string, dirInfo os.FileInfo, err error) error {
+		if dirInfo != nil {
+			namesPath := fmt.Sprintf("%s/%s/timeZoneNames.json", dir, dirInfo.Name())
+			if fileExists(namesPath) {
+				all = append(all, readTimezoneNames(namesPath)...)
+			}
+		}
+		return nil
+	})
+
+	slice.Sort(all[:], func(i, j int) bool {
+		return all[i].Locale < all[j].Locale
+	})
+	return all
+}
+
+func readTimezoneNames(file string) []TimezoneNames {
+	data := CldrTimeZoneNamesFile{}
+	err := json.Unmarshal(common.ReadFile(file), &data)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshall cldr time zone names: %s", err))
+
+	all := []TimezoneNames{}
+	for locale, main := range data.Main {
+		names := main.Dates.TimeZoneNames
+
+		metazones := map[string]MetazoneNames{}
+		for id, m := range names.Metazone {
+			if m.Long != (MetazoneNames{}) {
+				metazones[id] = m.Long
+			}
+		}
+
+		// Zones override their metazone's names where they differ (e.g.
+		// "British Summer Time" for Europe/London)
+		zones := map[string]MetazoneNames{}
+		cities := map[string]string{}
+		walkCldrZones("", names.Zone, func(name string, raw json.RawMessage) bool {
+			fields := map[string]json.RawMessage{}
+			if json.Unmarshal(raw, &fields) != nil {
+				return false
+			}
+			city, hasCity := fields["exemplarCity"]
+			long, hasLong := fields["long"]
+			if !hasCity && !hasLong {
+				return false
+			}
+			var value string
+			if hasCity && json.Unmarshal(city, &value) == nil && value != "" {
+				cities[name] = value
+			}
+			var longNames MetazoneNames
+			if hasLong && json.Unmarshal(long, &longNames) == nil && longNames != (MetazoneNames{}) {
+				zones[name] = longNames
+			}
+			return true
+		})
+
+		all = append(all, TimezoneNames{
+			Locale:               locale,
+			HourFormat:           names.HourFormat,
+			GmtFormat:            names.GmtFormat,
+			GmtZeroFormat:        names.GmtZeroFormat,
+			RegionFormat:         names.RegionFormat,
+			RegionStandardFormat: names.RegionStandardFormat,
+			RegionDaylightFormat: names.RegionDaylightFormat,
+			Metazones:            metazones,
+			Zones:                zones,
+			ExemplarCities:       cities,
+		})
+	}
+	return all
+}
+
+// walkCldrZones Visits the zones in CLDR's nested representation of zone
+// names (e.g. {"America": {"Argentina": {"Salta": ...}}}). 'leaf' is
+// called with the full zone name and returns true if it consumed the
+// value; otherwise the value is walked as a further level of nesting.
+func walkCldrZones(prefix string, zones map[string]json.RawMessage, leaf func(name string, raw json.RawMessage) bool) {
+	for key, raw := range zones {
+		name := key
+		if prefix != "" {
+			name = prefix + "/" + key
+		}
+		if leaf(name, raw) {
+			continue
+		}
+		children := map[string]json.RawMessage{}
+		if json.Unmarshal(raw, &children) == nil {
+			walkCldrZones(name, children, leaf)
+		}
+	}
+}
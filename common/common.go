@@ -8,7 +8,7 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
-	"regexp"
+	"path/filepath"
 	"strings"
 	"io"
 
@@ -35,23 +35,50 @@ type Continent struct {
 }
 
 type Country struct {
-	Name                 string   `json:"name"`
-	Iso_3166_2           string   `json:"iso_3166_2"`
-	Iso_3166_3           string   `json:"iso_3166_3"`
-	MeasurementSystem    string   `json:"measurement_system"`
-	DefaultCurrency      string   `json:"default_currency,omitempty"`
-	DefaultLanguage      string   `json:"default_language,omitempty"`
-	Languages            []string `json:"languages"`
-	Timezones            []string `json:"timezones"`
-	DefaultDeliveredDuty string   `json:"default_delivered_duty,omitempty"`
+	Name                  string            `json:"name"`
+	Iso_3166_2            string            `json:"iso_3166_2"`
+	Iso_3166_3            string            `json:"iso_3166_3"`
+	Iso_3166_N            string            `json:"iso_3166_numeric,omitempty"`
+	M49                   string            `json:"m49,omitempty"`
+	Fips                  string            `json:"fips,omitempty"`
+	Ioc                   string            `json:"ioc,omitempty"`
+	Fifa                  string            `json:"fifa,omitempty"`
+	Itu                   string            `json:"itu,omitempty"`
+	DialingCode           string            `json:"dialing_code,omitempty"`
+	Tld                   string            `json:"tld,omitempty"`
+	Capital               string            `json:"capital,omitempty"`
+	GeonameId             int               `json:"geoname_id,omitempty"`
+	Population            int64             `json:"population,omitempty"`
+	Flag                  CountryFlag       `json:"flag"`
+	MeasurementSystem     string            `json:"measurement_system"`
+	DefaultCurrency       string            `json:"default_currency,omitempty"`
+	DefaultCurrencyReason string            `json:"default_currency_reason,omitempty"`
+	LocalCurrency         string            `json:"local_currency,omitempty"`
+	Currencies            []string          `json:"currencies,omitempty"`
+	DefaultLanguage       string            `json:"default_language,omitempty"`
+	DefaultLocale         string            `json:"default_locale,omitempty"`
+	Languages             []string          `json:"languages"`
+	LanguagePopulations   []CountryLanguage `json:"language_populations,omitempty"`
+	Timezones             []string          `json:"timezones"`
+	TimezoneLocations     []CountryTimezone `json:"timezone_locations,omitempty"`
+	CurrencyHistory       []CountryCurrency `json:"currency_history,omitempty"`
+	DefaultDeliveredDuty  string            `json:"default_delivered_duty,omitempty"`
+	Telephone             *CountryTelephone `json:"telephone,omitempty"`
 }
 
 type Currency struct {
-	Name           string           `json:"name"`
-	Iso_4217_3     string           `json:"iso_4217_3"`
-	NumberDecimals int              `json:"number_decimals"`
-	Symbols        *CurrencySymbols `json:"symbols,omitempty"`
-	DefaultLocale  string           `json:"default_locale,omitempty"`
+	Name                string                     `json:"name"`
+	Iso_4217_3          string                     `json:"iso_4217_3"`
+	NumberDecimals      int                        `json:"number_decimals"`
+	Digits              int                        `json:"digits"`
+	Rounding            int                        `json:"rounding"`
+	CashDigits          int                        `json:"cash_digits"`
+	CashRounding        int                        `json:"cash_rounding"`
+	Status              string                     `json:"status"`
+	Symbols             *CurrencySymbols           `json:"symbols,omitempty"`
+	LocalizedSymbols    map[string]CurrencySymbols `json:"localized_symbols,omitempty"`
+	DefaultLocale       string                     `json:"default_locale,omitempty"`
+	DefaultLocaleReason string                     `json:"default_locale_reason,omitempty"`
 }
 
 type CurrencySymbols struct {
@@ -59,11 +86,22 @@ type CurrencySymbols struct {
 	Narrow  string `json:"narrow,omitempty"`
 }
 
+// Iso_639_2 repeats Iso_639_1 under the key that languages.json used for
+// the two letter code before the ISO 639-2 codes were added.
 type Language struct {
-	Name      string   `json:"name"`
-	Iso_639_2 string   `json:"iso_639_2"`
-	Countries []string `json:"countries"`
-	Locales   []string `json:"locales"`
+	Name          string            `json:"name"`
+	Iso_639_1     string            `json:"iso_639_1"`
+	Iso_639_2     string            `json:"iso_639_2"` // Deprecated: Use Iso_639_1
+	Iso_639_2B    string            `json:"iso_639_2b"`
+	Iso_639_2T    string            `json:"iso_639_2t"`
+	Iso_639_3     string            `json:"iso_639_3"`
+	Script        string            `json:"script,omitempty"`
+	Direction     string            `json:"direction"`
+	DefaultLocale string            `json:"default_locale,omitempty"`
+	Autonym       string            `json:"autonym,omitempty"`
+	Names         map[string]string `json:"names,omitempty"`
+	Countries     []string          `json:"countries"`
+	Locales       []string          `json:"locales"`
 }
 
 type PaymentMethod struct {
@@ -112,22 +150,40 @@ type Region struct {
 }
 
 type Timezone struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Offset      int    `json:"offset"`
+	Name                 string               `json:"name"`
+	Description          string               `json:"description"`
+	Offset               int                  `json:"offset"`
+	DaylightOffset       *int                 `json:"daylight_offset,omitempty"`
+	Abbreviation         string               `json:"abbreviation,omitempty"`
+	DaylightAbbreviation string               `json:"daylight_abbreviation,omitempty"`
+	Aliases              []string             `json:"aliases,omitempty"`
+	WindowsId            string               `json:"windows_id,omitempty"`
+	Metazone             string               `json:"metazone,omitempty"`
+	Rule                 string               `json:"rule,omitempty"`
+	Year                 int                  `json:"year,omitempty"` // The year described by the offsets, abbreviations and transitions
+	Transitions          []TimezoneTransition `json:"transitions,omitempty"`
 }
 
 type Locale struct {
-	Id       string        `json:"id"`
-	Name     string        `json:"name"`
-	Country  string        `json:"country"`
-	Language string        `json:"language,omitempty"`
-	Numbers  LocaleNumbers `json:"numbers"`
+	Id        string            `json:"id"`
+	Aliases   []string          `json:"aliases,omitempty"` // Previous ids, e.g. "pt" for "pt-PT"
+	Name      string            `json:"name"`
+	Country   string            `json:"country,omitempty"`
+	Language  string            `json:"language,omitempty"`
+	Script    string            `json:"script,omitempty"`
+	Region    string            `json:"region,omitempty"`
+	Variants  []string          `json:"variants,omitempty"`
+	Fallbacks []string          `json:"fallbacks"`
+	Direction string            `json:"direction"`
+	Autonym   string            `json:"autonym,omitempty"`
+	Names     map[string]string `json:"names,omitempty"`
+	Numbers   LocaleNumbers     `json:"numbers"`
 }
 
 type LocaleNumbers struct {
-	Decimal string `json:"decimal"`
-	Group   string `json:"group"`
+	Decimal  string `json:"decimal"`
+	Group    string `json:"group"`
+	Currency string `json:"currency,omitempty"` // CLDR currency pattern, e.g. "#,##0.00 ¤"
 }
 
 func Carriers() []Carrier {
@@ -165,6 +221,15 @@ func Currencies() []Currency {
 	return currencies
 }
 
+// HistoricCurrencies returns the currencies countries have used that are
+// not in Currencies, e.g. those listed in a country's currency history
+func HistoricCurrencies() []Currency {
+	currencies := []Currency{}
+	err := json.Unmarshal(readDataFileFromUrl("historic-currencies.json"), &currencies)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal historic currencies: %s", err))
+	return currencies
+}
+
 func Languages() []Language {
 	languages := []Language{}
 	err := json.Unmarshal(readDataFileFromUrl("languages.json"), &languages)
@@ -186,6 +251,41 @@ func Timezones() []Timezone {
 	return timezones
 }
 
+func AllTimezoneNames() []TimezoneNames {
+	names := []TimezoneNames{}
+	err := json.Unmarshal(readDataFileFromUrl("timezone-names.json"), &names)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal timezone names: %s", err))
+	return names
+}
+
+func CurrencyRemappings() []CurrencyRemappingProfile {
+	profiles := []CurrencyRemappingProfile{}
+	err := json.Unmarshal(readDataFileFromUrl("currency-remappings.json"), &profiles)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal currency remappings: %s", err))
+	return profiles
+}
+
+func WindowsZones() []WindowsZone {
+	windowsZones := []WindowsZone{}
+	err := json.Unmarshal(readDataFileFromUrl("windows-zones.json"), &windowsZones)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal windows zones: %s", err))
+	return windowsZones
+}
+
+func ParentLocales() map[string]string {
+	parentLocales := map[string]string{}
+	err := json.Unmarshal(readDataFileFromUrl("parent-locales.json"), &parentLocales)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal parent locales: %s", err))
+	return parentLocales
+}
+
+func AllLanguageTagAliases() LanguageTagAliases {
+	aliases := LanguageTagAliases{}
+	err := json.Unmarshal(readDataFileFromUrl("language-tag-aliases.json"), &aliases)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal language tag aliases: %s", err))
+	return aliases
+}
+
 func PaymentMethods() []PaymentMethod {
 	paymentMethods := []PaymentMethod{}
 	err := json.Unmarshal(readDataFileFromUrl("payment-methods.json"), &paymentMethods)
@@ -209,7 +309,7 @@ func Regions() []Region {
 
 func readDataFileFromUrl(name string) []byte {
 	// Add a random query parameter to flush cache in github
-	url := fmt.Sprintf("https://raw.githubusercontent.com/flowcommerce/json-reference/master/data/final/%s?r=%i", name, rand.Float64())
+	url := fmt.Sprintf("https://raw.githubusercontent.com/flowcommerce/json-reference/master/data/final/%s?r=%v", name, rand.Float64())
 	return ReadUrl(url)
 }
 
@@ -273,12 +373,21 @@ func FilterNonEmpty(vs []string) []string {
 }
 
 func WriteJson(target string, data interface{}) {
+	WriteJsonAs(target, data, data)
+}
+
+// WriteJsonAs writes the data as WriteJson does, validating it against the
+// schema of the Go type of 'as' rather than of data, for data whose type
+// does not describe it, e.g. a []interface{} of countries described by
+// []Country{}
+func WriteJsonAs(target string, as interface{}, data interface{}) {
 	tmp, err := ioutil.TempFile("", "reference-csv-to-json")
 	util.ExitIfError(err, "Error creating temporary file")
 	defer tmp.Close()
 
 	v, err := json.MarshalIndent(&data, "", "  ")
 	util.ExitIfError(err, "Error marshalling record to json")
+	validateJsonFile(target, as, v)
 
 	w := bufio.NewWriter(tmp)
 	_, err = w.Write(v)
@@ -301,16 +410,93 @@ func WriteJson(target string, data interface{}) {
 	}
 }
 
-func FormatLocaleId(value string) string {
-	formatted := regexp.MustCompile("_").ReplaceAllString(value, "-")
-	distinct := []string{}
-	for _, v := range strings.Split(formatted, "-") {
-		if !ContainsIgnoreCase(distinct, v) {
-			distinct = append(distinct, v)
-		}
+// MarshalJson marshals the data without indentation, validating it as
+// WriteJson does. The target names the file whose schema applies, which
+// may be shared by several files (e.g. one schema for every locale file)
+func MarshalJson(target string, data interface{}) []byte {
+	v, err := json.Marshal(&data)
+	util.ExitIfError(err, "Error marshalling record to json")
+	validateJsonFile(target, data, v)
+	return v
+}
+
+// Validates the json 'v' against the committed schema of the target (see
+// JsonSchemaPath), and fails if the schema of the Go type of 'as' differs
+// from it, so that a change in the shape of a file fails the
+// run until its schema is updated. With UPDATE_SCHEMAS=true, the schema is
+// first rewritten from the Go type
+func validateJsonFile(target string, as interface{}, v []byte) {
+	schema := JsonSchemaFor(filepath.Base(target), as)
+	if os.Getenv("UPDATE_SCHEMAS") == "true" {
+		writeJsonSchema(target, schema)
 	}
 
-	return strings.Join(distinct, "-")
+	path := JsonSchemaPath(target)
+	committed, err := readJsonSchema(path)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to read the schema of %s: %s. Run with UPDATE_SCHEMAS=true to write it\n", target, err)
+		os.Exit(1)
+	}
+
+	var decoded interface{}
+	err = json.Unmarshal(v, &decoded)
+	util.ExitIfError(err, "Error unmarshalling json to validate")
+	if err := ValidateJson(committed, decoded); err != nil {
+		fmt.Printf("ERROR: Failed to validate json for file %s against %s: %s\n", target, path, err)
+		os.Exit(1)
+	}
+
+	expected, err := json.Marshal(committed)
+	util.ExitIfError(err, fmt.Sprintf("Error marshalling json schema %s", path))
+	actual, err := json.Marshal(schema)
+	util.ExitIfError(err, fmt.Sprintf("Error marshalling json schema for %s", target))
+	if string(expected) != string(actual) {
+		fmt.Printf("ERROR: The shape of %s no longer matches %s. Run with UPDATE_SCHEMAS=true and review the schema changes\n", target, path)
+		os.Exit(1)
+	}
+}
+
+func readJsonSchema(path string) (*JsonSchema, error) {
+	v, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	schema := &JsonSchema{}
+	if err := json.Unmarshal(v, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// The schema of data/final/countries.json is written to
+// data/schema/final/countries.schema.json
+func JsonSchemaPath(target string) string {
+	path := strings.TrimSuffix(target, filepath.Ext(target)) + ".schema.json"
+	if rel, err := filepath.Rel("data", path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("data", "schema", rel)
+	}
+	return path
+}
+
+func writeJsonSchema(target string, schema *JsonSchema) {
+	path := JsonSchemaPath(target)
+	v, err := json.MarshalIndent(schema, "", "  ")
+	util.ExitIfError(err, fmt.Sprintf("Error marshalling json schema for %s", target))
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	util.ExitIfError(err, fmt.Sprintf("Error creating directory for %s", path))
+	err = ioutil.WriteFile(path, append(v, '\n'), 0644)
+	util.ExitIfError(err, fmt.Sprintf("Error writing json schema %s", path))
+}
+
+// FormatLocaleId formats a CLDR locale id as a canonical BCP 47 language
+// tag, e.g. "pt_PT" => "pt-PT". Ids that are not language tags (e.g.
+// "root") are returned with '_' replaced by '-'
+func FormatLocaleId(value string) string {
+	id := strings.Replace(value, "_", "-", -1)
+	if tag, err := ParseLanguageTag(id); err == nil {
+		return tag.String()
+	}
+	return id
 }
 
 func FormatUnderscore(value string) string {
@@ -339,49 +525,6 @@ func UnsupportedCountryCodes() []string {
 	}
 }
 
-// Need to map some currency codes into the ones supported  by
-// most payment processors
-var remappedCurrencyCodes = map[string]string{
-	"AFN": "EUR",
-	"ALK": "EUR",
-	"BIF": "EUR",
-	"BYR": "EUR",
-	"CNH": "EUR",
-	"CNX": "EUR",
-	"CUP": "EUR",
-	"CUP,CUC": "EUR",
-	"CDF": "EUR",
-	"ERN": "EUR",
-	"ILR": "EUR",
-	"IQD": "EUR",
-	"IRR": "EUR",
-	"ISJ": "EUR",
-	"KPW": "EUR",
-	"LRD": "EUR",
-	"MGA": "EUR",
-	"MKD": "EUR",
-	"MRU": "EUR",
-	"MVP": "EUR",
-	"MZN": "EUR",
-	"SDG": "EUR",
-	"SRD": "EUR",
-	"SSP": "EUR",
-	"STN": "EUR",
-	"SYP": "EUR",
-	"TJS": "EUR",
-	"TMT": "EUR",
-	"ZWL": "EUR",
-}
-
-func RemapCurrencyCodeToSupported(code string) string {
-	newCurrency, _ := remappedCurrencyCodes[code]
-	if newCurrency == "" {
-		return code
-	} else {
-		return newCurrency
-	}
-}
-
 //This method is executed for linux OS
 //Reference for this method: https://gist.github.com/var23rav/23ae5d0d4d830aff886c3c970b8f6c6b
 func MoveFile(sourcePath, destPath string) error {
@@ -0,0 +1,140 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type CountryFlag struct {
+	Emoji  string            `json:"emoji"`
+	Images CountryFlagImages `json:"images"`
+}
+
+type CountryFlagImages struct {
+	Small  CountryFlagImage `json:"small"`
+	Medium CountryFlagImage `json:"medium"`
+	Large  CountryFlagImage `json:"large"`
+}
+
+// Width and Height are those of the box the flag fits in. Flags keep their
+// proportions, so fill it in one dimension only (e.g. CHE is square)
+type CountryFlagImage struct {
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+const (
+	MeasurementSystemMetric   = "metric"
+	MeasurementSystemImperial = "imperial"
+)
+
+var MeasurementSystems = []string{MeasurementSystemMetric, MeasurementSystemImperial}
+
+// Who pays duties on delivery by default, set in data/original/country-duties.csv
+var DeliveredDuties = []string{"paid", "unpaid"}
+
+// Province types, normalized from the ISO 3166-2 subdivision categories
+const ProvinceTypeOther = "other"
+
+var ProvinceTypes = []string{
+	"city",
+	"dependency",
+	"district",
+	"emirate",
+	"entity",
+	"municipality",
+	"outlying_area",
+	"parish",
+	"province",
+	"state",
+	"territory",
+	ProvinceTypeOther,
+}
+
+const (
+	CountryIdentifierIso_3166_2 = "iso_3166_2"
+	CountryIdentifierIso_3166_3 = "iso_3166_3"
+	CountryIdentifierIso_3166_N = "iso_3166_numeric"
+	CountryIdentifierM49        = "m49"
+	CountryIdentifierFips       = "fips"
+	CountryIdentifierIoc        = "ioc"
+	CountryIdentifierFifa       = "fifa"
+	CountryIdentifierItu        = "itu"
+)
+
+// FindCountryByNumericCode returns the country with the given ISO 3166-1
+// numeric code. Accepts codes with or without leading zeros (e.g. "36"
+// or "036")
+func FindCountryByNumericCode(countries []Country, code string) (Country, error) {
+	return FindCountryByIdentifier(countries, CountryIdentifierIso_3166_N, code)
+}
+
+// FindCountryByIdentifier returns the country whose identifier of the given
+// type (one of the CountryIdentifier constants) matches value. The type
+// must be explicit as the code systems overlap - e.g. "AU" is Australia in
+// ISO 3166-1 but Austria in FIPS 10-4
+func FindCountryByIdentifier(countries []Country, identifier string, value string) (Country, error) {
+	for _, c := range countries {
+		actual, err := countryIdentifier(c, identifier)
+		if err != nil {
+			return Country{}, err
+		}
+		if actual != "" && equalsIdentifier(identifier, actual, value) {
+			return c, nil
+		}
+	}
+	return Country{}, fmt.Errorf("Country with %s[%s] not found", identifier, value)
+}
+
+func countryIdentifier(c Country, identifier string) (string, error) {
+	switch identifier {
+	case CountryIdentifierIso_3166_2:
+		return c.Iso_3166_2, nil
+	case CountryIdentifierIso_3166_3:
+		return c.Iso_3166_3, nil
+	case CountryIdentifierIso_3166_N:
+		return c.Iso_3166_N, nil
+	case CountryIdentifierM49:
+		return c.M49, nil
+	case CountryIdentifierFips:
+		return c.Fips, nil
+	case CountryIdentifierIoc:
+		return c.Ioc, nil
+	case CountryIdentifierFifa:
+		return c.Fifa, nil
+	case CountryIdentifierItu:
+		return c.Itu, nil
+	}
+	return "", fmt.Errorf("Invalid country identifier[%s]", identifier)
+}
+
+func equalsIdentifier(identifier string, actual string, value string) bool {
+	if identifier == CountryIdentifierIso_3166_N || identifier == CountryIdentifierM49 {
+		a, err := strconv.Atoi(actual)
+		if err != nil {
+			return false
+		}
+		v, err := strconv.Atoi(strings.TrimSpace(value))
+		return err == nil && a == v
+	}
+	return EqualsIgnoreCase(actual, strings.TrimSpace(value))
+}
+
+// FlagEmoji returns the flag emoji for an ISO 3166-1 alpha-2 code - the
+// pair of regional indicator symbols for its letters (e.g. "FR" => 🇫🇷)
+func FlagEmoji(iso_3166_2 string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(iso_3166_2))
+	if len(code) != 2 {
+		return "", fmt.Errorf("Country code[%s] must be two letters", iso_3166_2)
+	}
+	var b strings.Builder
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("Country code[%s] must be two letters", iso_3166_2)
+		}
+		b.WriteRune(0x1F1E6 + (r - 'A'))
+	}
+	return b.String(), nil
+}
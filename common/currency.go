@@ -0,0 +1,343 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	CurrencyStatusActive   = "active"
+	CurrencyStatusHistoric = "historic"
+)
+
+// Why a country's default (settlement) currency was chosen
+const (
+	// The currency in everyday use in the country
+	DefaultCurrencyReasonLocal = "local_currency"
+	// Another official currency that circulates alongside the local one
+	DefaultCurrencyReasonCirculating = "circulating_currency"
+	// The local currency is not supported by most payment processors
+	DefaultCurrencyReasonUnsupported = "unsupported_local_currency"
+)
+
+// Why a currency's default locale was chosen
+const (
+	// Set in data/original/currency-locales.csv
+	DefaultLocaleReasonOverride = "override"
+	// The default locale of the most populous country using the currency
+	DefaultLocaleReasonMostPopulousCountry = "most_populous_country"
+)
+
+// The remapping profile used for country default currencies
+const DefaultCurrencyRemappingProfile = "default"
+
+// A named set of rules mapping currencies to the ones a payment
+// processor (or set of processors) supports
+type CurrencyRemappingProfile struct {
+	Profile string              `json:"profile"`
+	Rules   []CurrencyRemapping `json:"rules"`
+}
+
+type CurrencyRemapping struct {
+	Currency  string `json:"currency"`
+	Target    string `json:"target"`
+	Rationale string `json:"rationale"`
+}
+
+// Format of the dates bounding a country's use of a currency
+const CurrencyDateFormat = "2006-01-02"
+
+// A currency used by a country over a period of time. From and To are
+// inclusive and empty when unbounded. Tender is false for currencies
+// that circulate but are not legal tender.
+type CountryCurrency struct {
+	Currency string `json:"currency"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Tender   bool   `json:"tender"`
+}
+
+// RoundAmount rounds an amount in the given currency using its CLDR
+// digits and rounding increment, or its cash digits and cash rounding
+// when 'cash' is true. For example, CHF cash amounts round to the
+// nearest 0.05.
+func RoundAmount(currency Currency, amount float64, cash bool) float64 {
+	digits := currency.Digits
+	increment := currency.Rounding
+	if cash {
+		digits = currency.CashDigits
+		increment = currency.CashRounding
+	}
+	if increment <= 0 {
+		increment = 1
+	}
+
+	scale := math.Pow10(digits)
+	units := math.Round(amount*scale/float64(increment)) * float64(increment)
+	return units / scale
+}
+
+// LegalTender returns the codes of the currencies that were legal tender
+// in the country on the given date, most recently introduced first
+func LegalTender(country Country, date time.Time) []string {
+	day := date.Format(CurrencyDateFormat)
+
+	codes := []string{}
+	for _, cc := range country.CurrencyHistory {
+		if !cc.Tender {
+			continue
+		}
+		if cc.From != "" && day < cc.From {
+			continue
+		}
+		if cc.To != "" && day > cc.To {
+			continue
+		}
+		if !Contains(codes, cc.Currency) {
+			codes = append(codes, cc.Currency)
+		}
+	}
+	return codes
+}
+
+// RemapCurrency returns the currency to use in place of 'code' under the
+// named profile, which is 'code' itself when the profile has no rule for it
+func RemapCurrency(profiles []CurrencyRemappingProfile, profile string, code string) (string, error) {
+	for _, p := range profiles {
+		if p.Profile != profile {
+			continue
+		}
+		for _, r := range p.Rules {
+			if EqualsIgnoreCase(r.Currency, code) {
+				return r.Target, nil
+			}
+		}
+		return code, nil
+	}
+	return "", fmt.Errorf("Currency remapping profile[%s] not found", profile)
+}
+
+// The rules of the default remapping profile when RemapCurrencyCodeToSupported
+// was deprecated, kept so that it does not need to load the published
+// profiles. Changes to data/original/currency-remappings.csv are not
+// reflected here
+var remappedCurrencyCodes = map[string]string{
+	"AFN":     "EUR",
+	"ALK":     "EUR",
+	"BIF":     "EUR",
+	"BYR":     "EUR",
+	"CNH":     "EUR",
+	"CNX":     "EUR",
+	"CUP":     "EUR",
+	"CUP,CUC": "EUR",
+	"CDF":     "EUR",
+	"ERN":     "EUR",
+	"ILR":     "EUR",
+	"IQD":     "EUR",
+	"IRR":     "EUR",
+	"ISJ":     "EUR",
+	"KPW":     "EUR",
+	"LRD":     "EUR",
+	"MGA":     "EUR",
+	"MKD":     "EUR",
+	"MRU":     "EUR",
+	"MVP":     "EUR",
+	"MZN":     "EUR",
+	"SDG":     "EUR",
+	"SRD":     "EUR",
+	"SSP":     "EUR",
+	"STN":     "EUR",
+	"SYP":     "EUR",
+	"TJS":     "EUR",
+	"TMT":     "EUR",
+	"ZWL":     "EUR",
+}
+
+// RemapCurrencyCodeToSupported returns the currency to use in place of
+// 'code' under the default remapping profile.
+//
+// Deprecated: Use RemapCurrency with the profiles from CurrencyRemappings
+func RemapCurrencyCodeToSupported(code string) string {
+	if target, ok := remappedCurrencyCodes[code]; ok {
+		return target
+	}
+	return code
+}
+
+// LocaleDefaultCurrency returns the default currency of the locale's
+// country (see LocaleCountry), e.g. EUR for "fr-FR" and, through its
+// language's default locale, MXN for "es-419"
+func LocaleDefaultCurrency(locale Locale, locales []Locale, languages []Language, countries []Country, currencies []Currency) (Currency, error) {
+	countryCode, err := LocaleCountry(locale, locales, languages)
+	if err != nil {
+		return Currency{}, err
+	}
+	for _, country := range countries {
+		if country.Iso_3166_3 != countryCode {
+			continue
+		}
+		if country.DefaultCurrency == "" {
+			return Currency{}, fmt.Errorf("Country[%s] has no default currency", countryCode)
+		}
+		for _, c := range currencies {
+			if c.Iso_4217_3 == country.DefaultCurrency {
+				return c, nil
+			}
+		}
+		return Currency{}, fmt.Errorf("Currency[%s] not found", country.DefaultCurrency)
+	}
+	return Currency{}, fmt.Errorf("Country[%s] not found", countryCode)
+}
+
+// LocalizedCurrencySymbols returns the symbols a shopper in the locale
+// expects for the currency, e.g. "$" for USD in "en-US", "US$" in "en-CA"
+// and "$US" in "fr-FR". Resolves through the locale's fallback chain, then
+// falls back to the currency's own symbols
+func LocalizedCurrencySymbols(currency Currency, locale Locale) (CurrencySymbols, error) {
+	for _, id := range localeChain(locale) {
+		if symbols, ok := currency.LocalizedSymbols[id]; ok {
+			return symbols, nil
+		}
+	}
+	if currency.Symbols != nil {
+		return *currency.Symbols, nil
+	}
+	return CurrencySymbols{}, fmt.Errorf("Currency[%s] has no symbol in locale[%s]", currency.Iso_4217_3, locale.Id)
+}
+
+// The CLDR currency pattern of the root locale, used for locales without
+// one
+const defaultCurrencyPattern = "¤#,##0.00"
+
+// FormatAmount formats an amount in the currency for display in the
+// locale, following the locale's CLDR currency pattern for the position of
+// the symbol and the spacing around it, e.g. 1234.5 USD is "$1,234.50" in
+// "en-US" and "1 234,50 $US" in "fr-FR". The amount is rounded to the
+// currency's CLDR digits. Currencies without a symbol are shown by their
+// ISO 4217 code
+func FormatAmount(currency Currency, locale Locale, amount float64) string {
+	symbol := currency.Iso_4217_3
+	if symbols, err := LocalizedCurrencySymbols(currency, locale); err == nil {
+		symbol = symbols.Primary
+	}
+
+	pattern := locale.Numbers.Currency
+	if pattern == "" {
+		pattern = defaultCurrencyPattern
+	}
+	subpatterns := strings.SplitN(pattern, ";", 2)
+	prefix, digits, suffix := SplitCurrencyPattern(subpatterns[0])
+
+	rounded := RoundAmount(currency, math.Abs(amount), false)
+	if amount < 0 && rounded != 0 {
+		if len(subpatterns) > 1 {
+			prefix, _, suffix = SplitCurrencyPattern(subpatterns[1])
+		} else {
+			prefix = "-" + prefix
+		}
+	}
+
+	number := strconv.FormatFloat(rounded, 'f', currency.Digits, 64)
+	integer, fraction := number, ""
+	if i := strings.Index(number, "."); i >= 0 {
+		integer, fraction = number[:i], number[i+1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(currencyAffix(prefix, symbol, true))
+	b.WriteString(groupDigits(integer, digits, locale.Numbers.Group))
+	if fraction != "" {
+		b.WriteString(locale.Numbers.Decimal)
+		b.WriteString(fraction)
+	}
+	b.WriteString(currencyAffix(suffix, symbol, false))
+	return b.String()
+}
+
+// SplitCurrencyPattern splits a CLDR number pattern (e.g. "#,##0.00 ¤")
+// into the text before the digits, the digits and the text after them
+func SplitCurrencyPattern(pattern string) (string, string, string) {
+	start := strings.IndexAny(pattern, "#0,.")
+	end := strings.LastIndexAny(pattern, "#0,.")
+	if start < 0 {
+		return pattern, "", ""
+	}
+	return pattern[:start], pattern[start : end+1], pattern[end+1:]
+}
+
+// CurrencyPatternFormats converts a CLDR currency pattern to the formats
+// of the javascript libraries, with '%s' in place of the currency sign and
+// '%v' in place of the digits: one for the symbol and one for the ISO 4217
+// code, e.g. "#,##0.00 ¤" => "%v %s". As in the CLDR currency spacing
+// rules, a code next to the digits is separated from them by a space
+func CurrencyPatternFormats(pattern string) (string, string) {
+	positive := strings.SplitN(pattern, ";", 2)[0]
+	prefix, _, suffix := SplitCurrencyPattern(positive)
+
+	codePrefix := strings.Replace(prefix, "¤", "%s", 1)
+	if strings.HasSuffix(prefix, "¤") {
+		codePrefix += " "
+	}
+	codeSuffix := strings.Replace(suffix, "¤", "%s", 1)
+	if strings.HasPrefix(suffix, "¤") {
+		codeSuffix = " " + codeSuffix
+	}
+
+	symbol := strings.Replace(prefix, "¤", "%s", 1) + "%v" + strings.Replace(suffix, "¤", "%s", 1)
+	return symbol, codePrefix + "%v" + codeSuffix
+}
+
+// Replaces the currency sign in the prefix or suffix of a pattern with the
+// symbol. Following the CLDR currency spacing rules, a no-break space
+// separates the digits from a symbol that does not end (or start) with a
+// symbol character, e.g. "CHF 1,234.50" but "$1,234.50"
+func currencyAffix(affix string, symbol string, prefix bool) string {
+	i := strings.Index(affix, "¤")
+	if i < 0 || symbol == "" {
+		return affix
+	}
+	runes := []rune(symbol)
+	if prefix && i+len("¤") == len(affix) {
+		if last := runes[len(runes)-1]; !unicode.IsSymbol(last) && !unicode.IsSpace(last) {
+			symbol += "\u00a0"
+		}
+	} else if !prefix && i == 0 {
+		if first := runes[0]; !unicode.IsSymbol(first) && !unicode.IsSpace(first) {
+			symbol = "\u00a0" + symbol
+		}
+	}
+	return strings.Replace(affix, "¤", symbol, 1)
+}
+
+// Groups the integer digits as the pattern does, e.g. "#,##0.00" in
+// threes and "#,##,##0.00" (Indian) in a three then twos
+func groupDigits(integer string, pattern string, separator string) string {
+	if i := strings.Index(pattern, "."); i >= 0 {
+		pattern = pattern[:i]
+	}
+	last := strings.LastIndex(pattern, ",")
+	if last < 0 {
+		return integer
+	}
+	primary := len(pattern) - last - 1
+	secondary := primary
+	if previous := strings.LastIndex(pattern[:last], ","); previous >= 0 {
+		secondary = last - previous - 1
+	}
+	if primary <= 0 || secondary <= 0 {
+		return integer
+	}
+
+	groups := []string{}
+	size := primary
+	for len(integer) > size {
+		groups = append([]string{integer[len(integer)-size:]}, groups...)
+		integer = integer[:len(integer)-size]
+		size = secondary
+	}
+	return strings.Join(append([]string{integer}, groups...), separator)
+}
@@ -0,0 +1,108 @@
+package common
+
+import "testing"
+
+func TestFormatAmount(t *testing.T) {
+	usd := Currency{
+		Iso_4217_3:     "USD",
+		NumberDecimals: 2,
+		Digits:         2,
+		Symbols:        &CurrencySymbols{Primary: "US$"},
+		LocalizedSymbols: map[string]CurrencySymbols{
+			"en": {Primary: "$"},
+			"fr": {Primary: "$US"},
+		},
+	}
+	jpy := Currency{Iso_4217_3: "JPY", NumberDecimals: 2, Digits: 0, Symbols: &CurrencySymbols{Primary: "¥"}}
+	chf := Currency{Iso_4217_3: "CHF", Digits: 2}
+	inr := Currency{Iso_4217_3: "INR", Digits: 2, Symbols: &CurrencySymbols{Primary: "₹"}}
+
+	enUS := Locale{Id: "en-US", Fallbacks: []string{"en", "root"}, Numbers: LocaleNumbers{Decimal: ".", Group: ",", Currency: "¤#,##0.00"}}
+	frFR := Locale{Id: "fr-FR", Fallbacks: []string{"fr", "root"}, Numbers: LocaleNumbers{Decimal: ",", Group: " ", Currency: "#,##0.00 ¤"}}
+	nlNL := Locale{Id: "nl-NL", Fallbacks: []string{"nl", "root"}, Numbers: LocaleNumbers{Decimal: ",", Group: ".", Currency: "¤ #,##0.00;¤ -#,##0.00"}}
+	hiIN := Locale{Id: "hi-IN", Fallbacks: []string{"hi", "root"}, Numbers: LocaleNumbers{Decimal: ".", Group: ",", Currency: "¤#,##,##0.00"}}
+	xx := Locale{Id: "xx-XX", Fallbacks: []string{"xx", "root"}, Numbers: LocaleNumbers{Decimal: ".", Group: ","}}
+
+	tests := []struct {
+		currency Currency
+		locale   Locale
+		amount   float64
+		want     string
+	}{
+		{usd, enUS, 1234.5, "$1,234.50"},
+		{usd, enUS, -1234.5, "-$1,234.50"},
+		{usd, enUS, 0.004, "$0.00"},
+		{usd, enUS, -0.004, "$0.00"},
+		{usd, enUS, 1234567.891, "$1,234,567.89"},
+		{usd, frFR, 1234.5, "1 234,50 $US"},
+		{usd, frFR, -1234.5, "-1 234,50 $US"},
+		{usd, nlNL, -1234.5, "US$ -1.234,50"},
+		{jpy, enUS, 1234.5, "¥1,235"},
+		{chf, enUS, 1234.5, "CHF 1,234.50"},
+		{chf, frFR, 1234.5, "1 234,50 CHF"},
+		{inr, hiIN, 12345678, "₹1,23,45,678.00"},
+		{usd, xx, 12.3, "US$12.30"},
+	}
+	for _, test := range tests {
+		if got := FormatAmount(test.currency, test.locale, test.amount); got != test.want {
+			t.Errorf("FormatAmount(%s, %s, %v) = %q, expected %q", test.currency.Iso_4217_3, test.locale.Id, test.amount, got, test.want)
+		}
+	}
+}
+
+func TestCurrencyPatternFormats(t *testing.T) {
+	tests := []struct {
+		pattern string
+		symbol  string
+		code    string
+	}{
+		{"¤#,##0.00", "%s%v", "%s %v"},
+		{"#,##0.00 ¤", "%v %s", "%v %s"},
+		{"¤ #,##0.00;¤ -#,##0.00", "%s %v", "%s %v"},
+		{"#,##0.00¤", "%v%s", "%v %s"},
+		{"¤#,##,##0.00", "%s%v", "%s %v"},
+	}
+	for _, test := range tests {
+		symbol, code := CurrencyPatternFormats(test.pattern)
+		if symbol != test.symbol || code != test.code {
+			t.Errorf("CurrencyPatternFormats(%q) = %q, %q, expected %q, %q", test.pattern, symbol, code, test.symbol, test.code)
+		}
+	}
+}
+
+func TestLocaleDefaultCurrency(t *testing.T) {
+	locales := []Locale{
+		{Id: "fr-FR", Country: "FRA", Language: "fr"},
+		{Id: "es-MX", Country: "MEX", Language: "es"},
+		{Id: "es-419", Language: "es", Region: "419", Fallbacks: []string{"es", "root"}},
+		{Id: "en-AQ", Country: "ATA", Language: "en"},
+		{Id: "zh-Hant", Language: "zh", Script: "Hant", Fallbacks: []string{"root"}},
+	}
+	languages := []Language{{Iso_639_1: "es", DefaultLocale: "es-MX"}}
+	countries := []Country{
+		{Iso_3166_3: "FRA", DefaultCurrency: "EUR"},
+		{Iso_3166_3: "MEX", DefaultCurrency: "MXN"},
+		{Iso_3166_3: "ATA"},
+	}
+	currencies := []Currency{{Iso_4217_3: "EUR"}, {Iso_4217_3: "MXN"}}
+
+	tests := []struct {
+		locale Locale
+		want   string
+	}{
+		{locales[0], "EUR"},
+		{locales[2], "MXN"},
+		{locales[3], ""},
+		{locales[4], ""},
+	}
+	for _, test := range tests {
+		got, err := LocaleDefaultCurrency(test.locale, locales, languages, countries, currencies)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("LocaleDefaultCurrency(%s) = %s, expected an error", test.locale.Id, got.Iso_4217_3)
+			}
+		} else if err != nil || got.Iso_4217_3 != test.want {
+			t.Errorf("LocaleDefaultCurrency(%s) = %s, %v, expected %s", test.locale.Id, got.Iso_4217_3, err, test.want)
+		}
+	}
+}
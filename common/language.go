@@ -0,0 +1,72 @@
+package common
+
+import (
+	"fmt"
+)
+
+const (
+	TextDirectionLeftToRight = "ltr"
+	TextDirectionRightToLeft = "rtl"
+)
+
+// CLDR official status of a language in a country
+const (
+	LanguageStatusOfficial         = "official"
+	LanguageStatusDeFactoOfficial  = "de_facto_official"
+	LanguageStatusOfficialRegional = "official_regional"
+	LanguageStatusOfficialMinority = "official_minority"
+)
+
+type CountryLanguage struct {
+	Language          string  `json:"language"`
+	PopulationPercent float64 `json:"population_percent"`
+	OfficialStatus    string  `json:"official_status,omitempty"`
+}
+
+// ISO 15924 codes of the scripts written right-to-left
+var rightToLeftScripts = []string{
+	"Adlm", "Arab", "Aran", "Armi", "Avst", "Chrs", "Cprt", "Elym", "Hatr",
+	"Hebr", "Hung", "Khar", "Lydi", "Mand", "Mani", "Mend", "Merc", "Mero",
+	"Narb", "Nbat", "Nkoo", "Orkh", "Ougr", "Palm", "Phli", "Phlp", "Phnx",
+	"Prti", "Rohg", "Samr", "Sarb", "Sogd", "Sogo", "Syrc", "Thaa", "Yezi",
+}
+
+// ScriptDirection returns the text direction ("ltr" or "rtl") of the
+// script with the given ISO 15924 code, suitable for an html dir attribute.
+// Unknown scripts are assumed to be left-to-right
+func ScriptDirection(script string) string {
+	if ContainsIgnoreCase(rightToLeftScripts, script) {
+		return TextDirectionRightToLeft
+	}
+	return TextDirectionLeftToRight
+}
+
+// LanguageName returns the name of the language in the given locale, e.g.
+// "allemand" for German in "fr-CA". Names are listed by language, and by
+// locale only where they differ, so lookups resolve through the locale's
+// fallback chain
+func LanguageName(language Language, locale Locale) (string, error) {
+	if name := localizedName(language.Names, locale); name != "" {
+		return name, nil
+	}
+	return "", fmt.Errorf("Language[%s] has no name in locale[%s]", language.Iso_639_1, locale.Id)
+}
+
+// LocaleName returns the name of the locale in the given display locale,
+// e.g. "Deutsch (Schweiz)" for "de-CH" in "de-AT". Resolves through the
+// display locale's fallback chain as for LanguageName
+func LocaleName(locale Locale, displayLocale Locale) (string, error) {
+	if name := localizedName(locale.Names, displayLocale); name != "" {
+		return name, nil
+	}
+	return "", fmt.Errorf("Locale[%s] has no name in locale[%s]", locale.Id, displayLocale.Id)
+}
+
+func localizedName(names map[string]string, locale Locale) string {
+	for _, id := range localeChain(locale) {
+		if name, ok := names[id]; ok {
+			return name
+		}
+	}
+	return ""
+}
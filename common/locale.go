@@ -0,0 +1,195 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RootLocale is the CLDR locale every fallback chain ends with
+const RootLocale = "root"
+
+// LanguageTag is a BCP 47 language tag (e.g. "zh-Hant-TW") split into its
+// subtags. Extensions and private use subtags are not supported.
+type LanguageTag struct {
+	Language string   `json:"language"`
+	Script   string   `json:"script,omitempty"`
+	Region   string   `json:"region,omitempty"`
+	Variants []string `json:"variants,omitempty"`
+}
+
+// LanguageTagAliases holds the CLDR replacements for deprecated or legacy
+// subtags, e.g. language "iw" => "he" and region "BU" => "MM"
+type LanguageTagAliases struct {
+	Languages map[string]string `json:"languages"`
+	Scripts   map[string]string `json:"scripts"`
+	Regions   map[string]string `json:"regions"`
+	Variants  map[string]string `json:"variants"`
+}
+
+var (
+	languageSubtag = regexp.MustCompile("^([a-zA-Z]{2,3}|[a-zA-Z]{5,8})$")
+	scriptSubtag   = regexp.MustCompile("^[a-zA-Z]{4}$")
+	regionSubtag   = regexp.MustCompile("^([a-zA-Z]{2}|[0-9]{3})$")
+	variantSubtag  = regexp.MustCompile("^([a-zA-Z0-9]{5,8}|[0-9][a-zA-Z0-9]{3})$")
+)
+
+// ParseLanguageTag parses a BCP 47 (or CLDR, using '_') language tag,
+// returning its subtags in canonical case: lowercase language and
+// variants, titlecase script and uppercase region
+func ParseLanguageTag(value string) (LanguageTag, error) {
+	subtags := strings.Split(strings.Replace(strings.TrimSpace(value), "_", "-", -1), "-")
+	if !languageSubtag.MatchString(subtags[0]) {
+		return LanguageTag{}, fmt.Errorf("Language tag[%s] has an invalid language subtag[%s]", value, subtags[0])
+	}
+
+	tag := LanguageTag{Language: strings.ToLower(subtags[0])}
+	rest := subtags[1:]
+	if len(rest) > 0 && scriptSubtag.MatchString(rest[0]) {
+		tag.Script = strings.ToUpper(rest[0][:1]) + strings.ToLower(rest[0][1:])
+		rest = rest[1:]
+	}
+	if len(rest) > 0 && regionSubtag.MatchString(rest[0]) {
+		tag.Region = strings.ToUpper(rest[0])
+		rest = rest[1:]
+	}
+	for _, v := range rest {
+		if !variantSubtag.MatchString(v) {
+			return LanguageTag{}, fmt.Errorf("Language tag[%s] has an invalid subtag[%s]", value, v)
+		}
+		tag.Variants = append(tag.Variants, strings.ToLower(v))
+	}
+	return tag, nil
+}
+
+// String formats the tag as a BCP 47 language tag, e.g. "sr-Latn-RS"
+func (t LanguageTag) String() string {
+	subtags := []string{t.Language}
+	if t.Script != "" {
+		subtags = append(subtags, t.Script)
+	}
+	if t.Region != "" {
+		subtags = append(subtags, t.Region)
+	}
+	return strings.Join(append(subtags, t.Variants...), "-")
+}
+
+// CanonicalizeLanguageTag parses the tag and replaces deprecated subtags
+// following the CLDR aliases (e.g. "iw-BU" => "he-MM"). A language
+// replacement may add a script or region that the tag does not specify
+// (e.g. "sh" => "sr-Latn"). A region that was split is replaced by the
+// first of its successors.
+func CanonicalizeLanguageTag(value string, aliases LanguageTagAliases) (LanguageTag, error) {
+	tag, err := ParseLanguageTag(value)
+	if err != nil {
+		return tag, err
+	}
+
+	if replacement, ok := aliases.Languages[tag.Language]; ok {
+		r, err := ParseLanguageTag(replacement)
+		if err != nil {
+			return tag, err
+		}
+		tag.Language = r.Language
+		if tag.Script == "" {
+			tag.Script = r.Script
+		}
+		if tag.Region == "" {
+			tag.Region = r.Region
+		}
+	}
+	if replacement, ok := aliases.Scripts[tag.Script]; ok {
+		tag.Script = replacement
+	}
+	if replacement, ok := aliases.Regions[tag.Region]; ok && len(strings.Fields(replacement)) > 0 {
+		tag.Region = strings.Fields(replacement)[0]
+	}
+	for i, v := range tag.Variants {
+		if replacement, ok := aliases.Variants[v]; ok {
+			tag.Variants[i] = replacement
+		}
+	}
+	return tag, nil
+}
+
+// LocaleFallbacks returns the CLDR fallback chain of the locale, excluding
+// the locale itself and ending with "root" (e.g. "en-IN" => ["en-001",
+// "en", "root"]). Each parent is taken from parentLocales when listed,
+// otherwise found by removing the last subtag.
+func LocaleFallbacks(id string, parentLocales map[string]string) []string {
+	fallbacks := []string{}
+	current := id
+	for current != RootLocale {
+		parent, ok := parentLocales[current]
+		if !ok {
+			if i := strings.LastIndex(current, "-"); i > 0 {
+				parent = current[:i]
+			} else {
+				parent = RootLocale
+			}
+		}
+		if parent == id || Contains(fallbacks, parent) {
+			break
+		}
+		fallbacks = append(fallbacks, parent)
+		current = parent
+	}
+	return fallbacks
+}
+
+// LocaleIdsByAlias maps the aliases of the locales (e.g. "pt" for
+// "pt-PT", see Locale.Aliases) to their ids, so that data keyed by locale
+// id can also be looked up under the previous ids. Aliases that are the
+// id of another locale are left out
+func LocaleIdsByAlias(locales []Locale) map[string]string {
+	ids := map[string]string{}
+	for _, l := range locales {
+		ids[l.Id] = l.Id
+	}
+	aliases := map[string]string{}
+	for _, l := range locales {
+		for _, alias := range l.Aliases {
+			if _, ok := ids[alias]; !ok {
+				aliases[alias] = l.Id
+			}
+		}
+	}
+	return aliases
+}
+
+// localeChain returns the locale id followed by its fallbacks, derived by
+// truncation when the locale does not list them
+func localeChain(locale Locale) []string {
+	fallbacks := locale.Fallbacks
+	if len(fallbacks) == 0 {
+		fallbacks = LocaleFallbacks(locale.Id, nil)
+	}
+	return append([]string{locale.Id}, fallbacks...)
+}
+
+// LocaleCountry returns the country of the locale. A locale without one
+// (e.g. "es-419", "zh-Hant") takes the country of the first locale in its
+// fallback chain that has one, otherwise of its language's default locale
+func LocaleCountry(locale Locale, locales []Locale, languages []Language) (string, error) {
+	if locale.Country != "" {
+		return locale.Country, nil
+	}
+	for _, id := range locale.Fallbacks {
+		for _, l := range locales {
+			if l.Id == id && l.Country != "" {
+				return l.Country, nil
+			}
+		}
+	}
+	for _, language := range languages {
+		if language.Iso_639_1 != locale.Language || language.DefaultLocale == "" {
+			continue
+		}
+		for _, l := range locales {
+			if l.Id == language.DefaultLocale && l.Country != "" {
+				return l.Country, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("Locale[%s] has no country", locale.Id)
+}
@@ -0,0 +1,181 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLanguageTag(t *testing.T) {
+	tests := []struct {
+		value string
+		want  LanguageTag
+	}{
+		{"en", LanguageTag{Language: "en"}},
+		{"pt-PT", LanguageTag{Language: "pt", Region: "PT"}},
+		{"pt_pt", LanguageTag{Language: "pt", Region: "PT"}},
+		{"zh-hant-tw", LanguageTag{Language: "zh", Script: "Hant", Region: "TW"}},
+		{"sr_Latn", LanguageTag{Language: "sr", Script: "Latn"}},
+		{"es-419", LanguageTag{Language: "es", Region: "419"}},
+		{"en-US-POSIX", LanguageTag{Language: "en", Region: "US", Variants: []string{"posix"}}},
+		{"ca-ES-valencia", LanguageTag{Language: "ca", Region: "ES", Variants: []string{"valencia"}}},
+		{" haw ", LanguageTag{Language: "haw"}},
+	}
+	for _, test := range tests {
+		got, err := ParseLanguageTag(test.value)
+		if err != nil {
+			t.Errorf("ParseLanguageTag(%q) failed: %s", test.value, err)
+		} else if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseLanguageTag(%q) = %+v, expected %+v", test.value, got, test.want)
+		}
+	}
+
+	for _, value := range []string{"", "root", "e", "en-US-x", "en--US", "1234"} {
+		if got, err := ParseLanguageTag(value); err == nil {
+			t.Errorf("ParseLanguageTag(%q) = %+v, expected an error", value, got)
+		}
+	}
+}
+
+func TestCanonicalizeLanguageTag(t *testing.T) {
+	aliases := LanguageTagAliases{
+		Languages: map[string]string{"iw": "he", "sh": "sr-Latn", "in": "id", "cnr": "sr-ME"},
+		Scripts:   map[string]string{"Qaai": "Zinh"},
+		Regions:   map[string]string{"BU": "MM", "SU": "RU AM AZ", "DD": "DE"},
+		Variants:  map[string]string{"heploc": "alalc97"},
+	}
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"iw-BU", "he-MM"},
+		{"sh", "sr-Latn"},
+		{"sh-Cyrl", "sr-Cyrl"},
+		{"cnr", "sr-ME"},
+		{"cnr-BA", "sr-BA"},
+		{"in_ID", "id-ID"},
+		{"ru-SU", "ru-RU"},
+		{"de-DD", "de-DE"},
+		{"und-Qaai", "und-Zinh"},
+		{"hy-heploc", "hy-alalc97"},
+		{"pt-PT", "pt-PT"},
+	}
+	for _, test := range tests {
+		tag, err := CanonicalizeLanguageTag(test.value, aliases)
+		if err != nil {
+			t.Errorf("CanonicalizeLanguageTag(%q) failed: %s", test.value, err)
+		} else if tag.String() != test.want {
+			t.Errorf("CanonicalizeLanguageTag(%q) = %q, expected %q", test.value, tag.String(), test.want)
+		}
+	}
+
+	if _, err := CanonicalizeLanguageTag("root", aliases); err == nil {
+		t.Errorf("CanonicalizeLanguageTag(\"root\") should fail")
+	}
+}
+
+func TestFormatLocaleId(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"pt_PT", "pt-PT"},
+		{"fr-FR", "fr-FR"},
+		{"zh_hant_tw", "zh-Hant-TW"},
+		{"en", "en"},
+		{"root", "root"},
+		{"en_US_x", "en-US-x"},
+	}
+	for _, test := range tests {
+		if got := FormatLocaleId(test.value); got != test.want {
+			t.Errorf("FormatLocaleId(%q) = %q, expected %q", test.value, got, test.want)
+		}
+	}
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	parents := map[string]string{
+		"en-IN":   "en-001",
+		"en-001":  "en",
+		"pt-AO":   "pt-PT",
+		"zh-Hant": "root",
+		"es-MX":   "es-419",
+	}
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{"en-IN", []string{"en-001", "en", "root"}},
+		{"pt-AO", []string{"pt-PT", "pt", "root"}},
+		{"zh-Hant-TW", []string{"zh-Hant", "root"}},
+		{"sr-Latn-BA", []string{"sr-Latn", "sr", "root"}},
+		{"es-MX", []string{"es-419", "es", "root"}},
+		{"de", []string{"root"}},
+		{"root", []string{}},
+	}
+	for _, test := range tests {
+		if got := LocaleFallbacks(test.id, parents); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("LocaleFallbacks(%q) = %v, expected %v", test.id, got, test.want)
+		}
+	}
+}
+
+func TestLocaleCountry(t *testing.T) {
+	locales := []Locale{
+		{Id: "es-ES", Aliases: []string{"es"}, Country: "ESP", Language: "es"},
+		{Id: "es-MX", Country: "MEX", Language: "es"},
+		{Id: "es-419", Language: "es", Region: "419", Fallbacks: []string{"es", "root"}},
+		{Id: "sr-Latn", Language: "sr", Script: "Latn", Fallbacks: []string{"root"}},
+		{Id: "sr-Latn-BA", Country: "BIH", Language: "sr", Script: "Latn", Fallbacks: []string{"sr-Latn", "root"}},
+		{Id: "zh-Hant", Language: "zh", Script: "Hant", Fallbacks: []string{"root"}},
+	}
+	languages := []Language{
+		{Iso_639_1: "es", DefaultLocale: "es-MX"},
+		{Iso_639_1: "sr", DefaultLocale: "sr-RS"},
+	}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"es-ES", "ESP"},
+		{"es-419", "MEX"},
+		{"sr-Latn-BA", "BIH"},
+		{"sr-Latn", ""},
+		{"zh-Hant", ""},
+	}
+	for _, test := range tests {
+		var locale Locale
+		for _, l := range locales {
+			if l.Id == test.id {
+				locale = l
+			}
+		}
+		got, err := LocaleCountry(locale, locales, languages)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("LocaleCountry(%s) = %q, expected an error", test.id, got)
+			}
+		} else if err != nil || got != test.want {
+			t.Errorf("LocaleCountry(%s) = %q, %v, expected %q", test.id, got, err, test.want)
+		}
+	}
+}
+
+func TestLocaleIdsByAlias(t *testing.T) {
+	locales := []Locale{
+		{Id: "pt-PT", Aliases: []string{"pt"}},
+		{Id: "zh-TW", Aliases: []string{"zh-Hant-TW"}},
+		{Id: "es-419"},
+		{Id: "en"},
+		{Id: "en-US", Aliases: []string{"en"}},
+	}
+	want := map[string]string{
+		"pt":         "pt-PT",
+		"zh-Hant-TW": "zh-TW",
+	}
+	if got := LocaleIdsByAlias(locales); !reflect.DeepEqual(got, want) {
+		t.Errorf("LocaleIdsByAlias = %v, expected %v", got, want)
+	}
+}
@@ -0,0 +1,295 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const JsonSchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// A JSON Schema document, or one of its subschemas. AdditionalProperties
+// is false for structs, and the schema of the values for maps
+type JsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 []string               `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Properties           map[string]*JsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *JsonSchema            `json:"items,omitempty"`
+	AnyOf                []*JsonSchema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*JsonSchema `json:"$defs,omitempty"`
+}
+
+// UnmarshalJSON decodes additionalProperties as either a bool or a schema,
+// as JsonSchemaFor generates them
+func (s *JsonSchema) UnmarshalJSON(v []byte) error {
+	type schema JsonSchema
+	decoded := struct {
+		*schema
+		AdditionalProperties json.RawMessage `json:"additionalProperties,omitempty"`
+	}{schema: (*schema)(s)}
+	if err := json.Unmarshal(v, &decoded); err != nil {
+		return err
+	}
+
+	s.AdditionalProperties = nil
+	if len(decoded.AdditionalProperties) == 0 {
+		return nil
+	}
+	var allowed bool
+	if err := json.Unmarshal(decoded.AdditionalProperties, &allowed); err == nil {
+		s.AdditionalProperties = allowed
+		return nil
+	}
+	additional := &JsonSchema{}
+	if err := json.Unmarshal(decoded.AdditionalProperties, additional); err != nil {
+		return err
+	}
+	s.AdditionalProperties = additional
+	return nil
+}
+
+// The allowed values of string fields (or of the elements of string
+// slices), by Go type name and json field name
+var schemaEnums = map[string][]string{
+	"Country.measurement_system":      MeasurementSystems,
+	"Country.default_delivered_duty":  DeliveredDuties,
+	"CountryDuty.duty":                DeliveredDuties,
+	"Country.default_currency_reason": {DefaultCurrencyReasonLocal, DefaultCurrencyReasonCirculating, DefaultCurrencyReasonUnsupported},
+	"Currency.status":                 {CurrencyStatusActive, CurrencyStatusHistoric},
+	"Currency.default_locale_reason":  {DefaultLocaleReasonOverride, DefaultLocaleReasonMostPopulousCountry},
+	"CountryLanguage.official_status": {LanguageStatusOfficial, LanguageStatusDeFactoOfficial, LanguageStatusOfficialRegional, LanguageStatusOfficialMinority},
+	"Province.province_type":          ProvinceTypes,
+	"Region.measurement_systems":      MeasurementSystems,
+}
+
+// JsonSchemaFor generates the schema of the json written for data (see
+// WriteJson) from its Go type, regardless of its value. Struct types are
+// defined in $defs. Fields without omitempty are required, and are
+// nullable if they are pointers, slices or maps. Interface types, such as
+// the elements of a []interface{}, accept any value; use WriteJsonAs to
+// describe such data by a concrete type.
+func JsonSchemaFor(title string, data interface{}) *JsonSchema {
+	root := &JsonSchema{
+		Schema: JsonSchemaVersion,
+		Title:  title,
+		Defs:   map[string]*JsonSchema{},
+	}
+	s := &JsonSchema{}
+	if t := reflect.TypeOf(data); t != nil {
+		s = schemaForType(root, t, "")
+	}
+	root.Ref, root.Type, root.Items, root.AnyOf, root.AdditionalProperties = s.Ref, s.Type, s.Items, s.AnyOf, s.AdditionalProperties
+	if len(root.Defs) == 0 {
+		root.Defs = nil
+	}
+	return root
+}
+
+// The schema of the type. 'enum' names the entry of schemaEnums that
+// applies to strings, if any
+func schemaForType(root *JsonSchema, t reflect.Type, enum string) *JsonSchema {
+	if t == reflect.TypeOf(time.Time{}) {
+		return &JsonSchema{Type: []string{"string"}, Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(schemaForType(root, t.Elem(), enum))
+	case reflect.String:
+		return &JsonSchema{Type: []string{"string"}, Enum: schemaEnums[enum]}
+	case reflect.Bool:
+		return &JsonSchema{Type: []string{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JsonSchema{Type: []string{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &JsonSchema{Type: []string{"number"}}
+	case reflect.Slice, reflect.Array:
+		return &JsonSchema{Type: []string{"array", "null"}, Items: schemaForType(root, t.Elem(), enum)}
+	case reflect.Map:
+		return &JsonSchema{Type: []string{"object", "null"}, AdditionalProperties: schemaForType(root, t.Elem(), "")}
+	case reflect.Struct:
+		return &JsonSchema{Ref: "#/$defs/" + defineStruct(root, t)}
+	}
+	// interface{} and anything else json can encode
+	return &JsonSchema{}
+}
+
+// Adds the schema of the struct to the root's $defs, returning its name
+func defineStruct(root *JsonSchema, t reflect.Type) string {
+	name := t.Name()
+	if name == "" || (root.Defs[name] != nil && root.Defs[name].Title != t.String()) {
+		name = strings.Replace(t.String(), ".", "_", -1)
+	}
+	if _, ok := root.Defs[name]; ok {
+		return name
+	}
+
+	s := &JsonSchema{
+		Title:                t.String(),
+		Type:                 []string{"object"},
+		Properties:           map[string]*JsonSchema{},
+		AdditionalProperties: false,
+	}
+	// Defined before the fields so that recursive types terminate
+	root.Defs[name] = s
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		field, omitempty, ok := jsonField(f)
+		if !ok {
+			continue
+		}
+		s.Properties[field] = schemaForType(root, f.Type, t.Name()+"."+field)
+		if !omitempty {
+			s.Required = append(s.Required, field)
+		}
+	}
+	sort.Strings(s.Required)
+	return name
+}
+
+func nullable(s *JsonSchema) *JsonSchema {
+	if s.Ref != "" {
+		return &JsonSchema{AnyOf: []*JsonSchema{s, {Type: []string{"null"}}}}
+	}
+	if len(s.Type) > 0 && !Contains(s.Type, "null") {
+		s.Type = append(s.Type, "null")
+	}
+	return s
+}
+
+// The json name of the field, and whether it is omitted when empty
+func jsonField(f reflect.StructField) (string, bool, bool) {
+	if f.PkgPath != "" {
+		return "", false, false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	return name, Contains(parts[1:], "omitempty"), true
+}
+
+// ValidateJson validates a decoded json value (as from json.Unmarshal into
+// an interface{}) against the schema, returning an error naming the path
+// of the first invalid value
+func ValidateJson(schema *JsonSchema, value interface{}) error {
+	return validateJson(schema, schema, value, "$")
+}
+
+func validateJson(root *JsonSchema, s *JsonSchema, value interface{}, path string) error {
+	if s.Ref != "" {
+		def, ok := root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			return fmt.Errorf("%s: schema reference[%s] not found", path, s.Ref)
+		}
+		return validateJson(root, def, value, path)
+	}
+
+	if len(s.AnyOf) > 0 {
+		var err error
+		for _, option := range s.AnyOf {
+			if err = validateJson(root, option, value, path); err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+
+	actual := jsonType(value)
+	if actual == "integer" && Contains(s.Type, "number") {
+		actual = "number"
+	}
+	if len(s.Type) > 0 && !Contains(s.Type, actual) {
+		return fmt.Errorf("%s: expected %s but found %s", path, strings.Join(s.Type, " or "), jsonType(value))
+	}
+	if len(s.Enum) > 0 {
+		if str, ok := value.(string); ok && !Contains(s.Enum, str) {
+			return fmt.Errorf("%s: value[%s] is not one of %s", path, str, strings.Join(s.Enum, ", "))
+		}
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		if s.Items != nil {
+			for i, e := range v {
+				if err := validateJson(root, s.Items, e, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		for _, field := range s.Required {
+			if _, ok := v[field]; !ok {
+				return fmt.Errorf("%s: missing required field[%s]", path, field)
+			}
+		}
+		keys := []string{}
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fieldPath := fmt.Sprintf("%s.%s", path, k)
+			if p, ok := s.Properties[k]; ok {
+				if err := validateJson(root, p, v[k], fieldPath); err != nil {
+					return err
+				}
+				continue
+			}
+			switch additional := s.AdditionalProperties.(type) {
+			case bool:
+				if !additional {
+					return fmt.Errorf("%s: unexpected field", fieldPath)
+				}
+			case *JsonSchema:
+				if err := validateJson(root, additional, v[k], fieldPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// The JSON Schema type of a decoded json value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type schemaTestRecord struct {
+	Id       string            `json:"id"`
+	Count    int               `json:"count"`
+	Ratio    float64           `json:"ratio,omitempty"`
+	Tags     []string          `json:"tags"`
+	Names    map[string]string `json:"names,omitempty"`
+	Parent   *schemaTestRecord `json:"parent,omitempty"`
+	Currency *Currency         `json:"currency,omitempty"`
+}
+
+func TestValidateJson(t *testing.T) {
+	schema := JsonSchemaFor("records.json", []schemaTestRecord{})
+
+	tests := []struct {
+		json  string
+		valid bool
+	}{
+		{`[]`, true},
+		{`null`, true},
+		{`[{"id": "a", "count": 1, "tags": ["x"]}]`, true},
+		{`[{"id": "a", "count": 1, "tags": null, "ratio": 0.5, "names": {"fr": "b"}}]`, true},
+		{`[{"id": "a", "count": 1, "tags": [], "parent": {"id": "b", "count": 2, "tags": []}}]`, true},
+		{`[{"id": "a", "count": 1, "tags": [], "parent": null}]`, true},
+		{`[{"id": "a", "count": 1, "tags": [], "currency": {"name": "Euro", "iso_4217_3": "EUR", "number_decimals": 2, "digits": 2, "rounding": 0, "cash_digits": 2, "cash_rounding": 0, "status": "active"}}]`, true},
+		{`{}`, false},
+		{`[{"count": 1, "tags": []}]`, false},
+		{`[{"id": "a", "count": 1.5, "tags": []}]`, false},
+		{`[{"id": "a", "count": "1", "tags": []}]`, false},
+		{`[{"id": "a", "count": 1, "tags": [1]}]`, false},
+		{`[{"id": "a", "count": 1, "tags": [], "extra": true}]`, false},
+		{`[{"id": "a", "count": 1, "tags": [], "names": {"fr": 1}}]`, false},
+		{`[{"id": "a", "count": 1, "tags": [], "parent": {"id": "b", "tags": []}}]`, false},
+		{`[{"id": "a", "count": 1, "tags": [], "currency": {"name": "Euro", "iso_4217_3": "EUR", "number_decimals": 2, "digits": 2, "rounding": 0, "cash_digits": 2, "cash_rounding": 0, "status": "withdrawn"}}]`, false},
+	}
+
+	for _, test := range tests {
+		var value interface{}
+		if err := json.Unmarshal([]byte(test.json), &value); err != nil {
+			t.Fatalf("Invalid test json %s: %s", test.json, err)
+		}
+		err := ValidateJson(schema, value)
+		if test.valid && err != nil {
+			t.Errorf("ValidateJson(%s) failed: %s", test.json, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("ValidateJson(%s) should fail", test.json)
+		}
+	}
+}
+
+func TestJsonSchemaRoundTrip(t *testing.T) {
+	for _, data := range []interface{}{
+		[]schemaTestRecord{},
+		map[string]CurrencySymbols{},
+		[]CountryLanguage{},
+		LanguageTagAliases{},
+	} {
+		schema := JsonSchemaFor("test.json", data)
+		v, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatalf("Failed to marshal schema: %s", err)
+		}
+		decoded := &JsonSchema{}
+		if err := json.Unmarshal(v, decoded); err != nil {
+			t.Fatalf("Failed to unmarshal schema %s: %s", v, err)
+		}
+		if !reflect.DeepEqual(decoded, schema) {
+			t.Errorf("Schema changed in a round trip: %s", v)
+		}
+	}
+}
+
+func TestJsonSchemaForIgnoresValues(t *testing.T) {
+	tests := []struct {
+		empty interface{}
+		data  interface{}
+	}{
+		{[]schemaTestRecord{}, []schemaTestRecord{{Id: "a", Parent: &schemaTestRecord{Id: "b"}}}},
+		{[]interface{}{}, []interface{}{CountryLanguage{}, Currency{}}},
+		{map[string]interface{}{}, map[string]interface{}{"a": CountryLanguage{}}},
+	}
+	for _, test := range tests {
+		if want, got := JsonSchemaFor("test.json", test.empty), JsonSchemaFor("test.json", test.data); !reflect.DeepEqual(got, want) {
+			t.Errorf("JsonSchemaFor(%T) depends on the value", test.data)
+		}
+	}
+
+	schema := JsonSchemaFor("test.json", []interface{}{})
+	if schema.Items == nil || !reflect.DeepEqual(*schema.Items, JsonSchema{}) {
+		t.Errorf("The elements of a []interface{} should accept any value")
+	}
+}
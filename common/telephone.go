@@ -0,0 +1,103 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AreaCodes and ExcludedAreaCodes are prefixes of the national number,
+// used when countries share a calling code (e.g. +1): a country with area
+// codes only accepts numbers starting with one of them, and the others
+// reject numbers starting with any area code of another country.
+type CountryTelephone struct {
+	CallingCode           string   `json:"calling_code"`
+	TrunkPrefix           string   `json:"trunk_prefix,omitempty"`
+	NationalNumberLengths []int    `json:"national_number_lengths"`
+	ExampleNumber         string   `json:"example_number"`
+	AreaCodes             []string `json:"area_codes,omitempty"`
+	ExcludedAreaCodes     []string `json:"excluded_area_codes,omitempty"`
+}
+
+// FormatE164 normalizes a phone number entered for the given country
+// into E.164 format (e.g. "+14155550123"). The number may be local,
+// include the country's trunk prefix, or already be in international
+// format (leading "+" or "00"), in which case a trunk prefix written in
+// parentheses is ignored (e.g. "+44 (0)20 7946 0958"). Returns an error if
+// the number cannot possibly be valid for the country.
+func FormatE164(country Country, number string) (string, error) {
+	t := country.Telephone
+	if t == nil {
+		return "", fmt.Errorf("Country[%s] has no telephone metadata", country.Iso_3166_3)
+	}
+
+	trimmed := strings.TrimSpace(number)
+	if t.TrunkPrefix != "" {
+		trimmed = strings.Replace(trimmed, "("+t.TrunkPrefix+")", "", 1)
+	}
+	international := strings.HasPrefix(trimmed, "+")
+	digits := onlyDigits(trimmed)
+	if digits == "" {
+		return "", errors.New("Phone number must contain digits")
+	}
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+
+	var national string
+	if international {
+		if !strings.HasPrefix(digits, t.CallingCode) {
+			return "", fmt.Errorf("Phone number[%s] does not start with calling code[%s] for country[%s]", number, t.CallingCode, country.Iso_3166_3)
+		}
+		national = digits[len(t.CallingCode):]
+	} else {
+		national = digits
+		if t.TrunkPrefix != "" && strings.HasPrefix(national, t.TrunkPrefix) {
+			withoutPrefix := national[len(t.TrunkPrefix):]
+			if isValidNationalNumberLength(t, withoutPrefix) {
+				national = withoutPrefix
+			}
+		}
+	}
+
+	if !isValidNationalNumberLength(t, national) {
+		return "", fmt.Errorf("Phone number[%s] has an invalid length for country[%s]", number, country.Iso_3166_3)
+	}
+	if len(t.AreaCodes) > 0 && !hasAnyPrefix(national, t.AreaCodes) {
+		return "", fmt.Errorf("Phone number[%s] does not start with an area code of country[%s]", number, country.Iso_3166_3)
+	}
+	if hasAnyPrefix(national, t.ExcludedAreaCodes) {
+		return "", fmt.Errorf("Phone number[%s] has the area code of another country sharing calling code[%s] with country[%s]", number, t.CallingCode, country.Iso_3166_3)
+	}
+
+	return fmt.Sprintf("+%s%s", t.CallingCode, national), nil
+}
+
+func isValidNationalNumberLength(t *CountryTelephone, national string) bool {
+	for _, l := range t.NationalNumberLengths {
+		if len(national) == l {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(value, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func onlyDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package common
+
+import "testing"
+
+func TestFormatE164(t *testing.T) {
+	usa := Country{Iso_3166_3: "USA", Telephone: &CountryTelephone{
+		CallingCode:           "1",
+		TrunkPrefix:           "1",
+		NationalNumberLengths: []int{10},
+		ExcludedAreaCodes:     []string{"242", "416", "876"},
+	}}
+	can := Country{Iso_3166_3: "CAN", Telephone: &CountryTelephone{
+		CallingCode:           "1",
+		TrunkPrefix:           "1",
+		NationalNumberLengths: []int{10},
+		AreaCodes:             []string{"416", "506"},
+	}}
+	gbr := Country{Iso_3166_3: "GBR", Telephone: &CountryTelephone{
+		CallingCode:           "44",
+		TrunkPrefix:           "0",
+		NationalNumberLengths: []int{9, 10},
+	}}
+	nor := Country{Iso_3166_3: "NOR", Telephone: &CountryTelephone{
+		CallingCode:           "47",
+		NationalNumberLengths: []int{8},
+	}}
+
+	tests := []struct {
+		country Country
+		number  string
+		want    string
+	}{
+		{usa, "(201) 555-0123", "+12015550123"},
+		{usa, "1 201 555 0123", "+12015550123"},
+		{usa, "+1 201 555 0123", "+12015550123"},
+		{usa, "001 201 555 0123", "+12015550123"},
+		{usa, "+1 416 555 0123", ""},
+		{usa, "876 555 0123", ""},
+		{usa, "201 555 012", ""},
+		{can, "416-555-0123", "+14165550123"},
+		{can, "+1 201 555 0123", ""},
+		{gbr, "020 7946 0958", "+442079460958"},
+		{gbr, "+44 (0)20 7946 0958", "+442079460958"},
+		{gbr, "+44 20 7946 0958", "+442079460958"},
+		{gbr, "0044 (0)20 7946 0958", "+442079460958"},
+		{gbr, "+33 1 23 45 67 89", ""},
+		{nor, "406 12 345", "+4740612345"},
+		{nor, "", ""},
+		{Country{Iso_3166_3: "XXX"}, "123", ""},
+	}
+
+	for _, test := range tests {
+		got, err := FormatE164(test.country, test.number)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("FormatE164(%s, %q) = %q, expected an error", test.country.Iso_3166_3, test.number, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FormatE164(%s, %q) failed: %s", test.country.Iso_3166_3, test.number, err)
+		} else if got != test.want {
+			t.Errorf("FormatE164(%s, %q) = %q, expected %q", test.country.Iso_3166_3, test.number, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TimezoneTransition struct {
+	At           time.Time `json:"at"`
+	Offset       int       `json:"offset"`
+	Abbreviation string    `json:"abbreviation"`
+}
+
+type CountryTimezone struct {
+	Timezone    string       `json:"timezone"`
+	Coordinates *Coordinates `json:"coordinates,omitempty"`
+	Comments    string       `json:"comments,omitempty"`
+}
+
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Maps a Windows timezone id to IANA zones for a territory. Territory is
+// an ISO 3166-1 alpha-2 code, or "001" for the default zone of the id.
+type WindowsZone struct {
+	WindowsId string   `json:"windows_id"`
+	Territory string   `json:"territory"`
+	Timezones []string `json:"timezones"`
+}
+
+// The CLDR territory code identifying the default mapping of a Windows id
+const WindowsZoneDefaultTerritory = "001"
+
+// Localized timezone names for a locale, from CLDR
+type TimezoneNames struct {
+	Locale               string                   `json:"locale"`
+	HourFormat           string                   `json:"hour_format"`
+	GmtFormat            string                   `json:"gmt_format"`
+	GmtZeroFormat        string                   `json:"gmt_zero_format,omitempty"`
+	RegionFormat         string                   `json:"region_format,omitempty"`
+	RegionStandardFormat string                   `json:"region_standard_format,omitempty"`
+	RegionDaylightFormat string                   `json:"region_daylight_format,omitempty"`
+	Metazones            map[string]MetazoneNames `json:"metazones"`
+	Zones                map[string]MetazoneNames `json:"zones,omitempty"`
+	ExemplarCities       map[string]string        `json:"exemplar_cities"`
+}
+
+type MetazoneNames struct {
+	Generic  string `json:"generic,omitempty"`
+	Standard string `json:"standard,omitempty"`
+	Daylight string `json:"daylight,omitempty"`
+}
+
+// FindTimezone returns the timezone with the given IANA name, resolving
+// links (e.g. "US/Pacific") to their canonical zone
+func FindTimezone(timezones []Timezone, name string) (Timezone, error) {
+	for _, tz := range timezones {
+		if EqualsIgnoreCase(tz.Name, name) || ContainsIgnoreCase(tz.Aliases, name) {
+			return tz, nil
+		}
+	}
+	return Timezone{}, fmt.Errorf("Timezone[%s] not found", name)
+}
+
+// OffsetAt returns the offset from UTC, in minutes, in effect in the
+// timezone at the given instant, following the zone's current daylight
+// saving rule (see Timezone.Rule). Earlier rule changes are not known, so
+// offsets for instants before the tzdata release may differ from history.
+func OffsetAt(timezone Timezone, at time.Time) (int, error) {
+	if timezone.Rule == "" {
+		if timezone.DaylightOffset != nil {
+			return 0, fmt.Errorf("Timezone[%s] observes daylight saving time but has no rule", timezone.Name)
+		}
+		return timezone.Offset, nil
+	}
+	rule, err := parseTimezoneRule(timezone.Rule)
+	if err != nil {
+		return 0, err
+	}
+	return rule.offsetAt(at) / 60, nil
+}
+
+// FormatOffset formats an offset in minutes as e.g. "+05:30"
+func FormatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, offset/60, offset%60)
+}
+
+// WindowsToIana returns the IANA zone for a Windows timezone id (e.g.
+// "Pacific Standard Time") as observed in the given territory (ISO 3166-1
+// alpha-2). Falls back to the default zone for the id when the territory
+// is empty or has no specific mapping.
+func WindowsToIana(windowsZones []WindowsZone, windowsId string, territory string) (string, error) {
+	var fallback string
+	for _, z := range windowsZones {
+		if !EqualsIgnoreCase(z.WindowsId, windowsId) || len(z.Timezones) == 0 {
+			continue
+		}
+		if territory != "" && EqualsIgnoreCase(z.Territory, territory) {
+			return z.Timezones[0], nil
+		}
+		if z.Territory == WindowsZoneDefaultTerritory {
+			fallback = z.Timezones[0]
+		}
+	}
+	if fallback == "" {
+		return "", fmt.Errorf("Windows timezone[%s] not found", windowsId)
+	}
+	return fallback, nil
+}
+
+// IanaToWindows returns the Windows timezone id for an IANA zone name,
+// resolving links (e.g. "Asia/Calcutta") through 'timezones' first. When
+// several Windows ids list the zone, prefers the default ("001") mapping.
+func IanaToWindows(windowsZones []WindowsZone, timezones []Timezone, name string) (string, error) {
+	if tz, err := FindTimezone(timezones, name); err == nil {
+		name = tz.Name
+	}
+
+	var windowsId string
+	for _, z := range windowsZones {
+		if !ContainsIgnoreCase(z.Timezones, name) {
+			continue
+		}
+		if z.Territory == WindowsZoneDefaultTerritory {
+			return z.WindowsId, nil
+		}
+		if windowsId == "" {
+			windowsId = z.WindowsId
+		}
+	}
+	if windowsId == "" {
+		return "", fmt.Errorf("No windows timezone for timezone[%s]", name)
+	}
+	return windowsId, nil
+}
+
+// FindTimezoneNames returns the timezone names for the locale, resolving
+// through its fallback chain (e.g. "fr-CA" => "fr")
+func FindTimezoneNames(all []TimezoneNames, locale Locale) (TimezoneNames, error) {
+	for _, id := range localeChain(locale) {
+		for _, n := range all {
+			if EqualsIgnoreCase(n.Locale, id) {
+				return n, nil
+			}
+		}
+	}
+	return TimezoneNames{}, fmt.Errorf("Locale[%s] has no timezone names", locale.Id)
+}
+
+// TimezoneLabel renders the name of a timezone in the locale of 'names' as
+// observed at the given instant, choosing standard or daylight wording.
+// Prefers the zone's own name (e.g. "British Summer Time" for
+// Europe/London), then its metazone's (e.g. "Central European Summer
+// Time"). Falls back to the exemplar city (e.g. "Paris Time"), then to the
+// offset (e.g. "GMT+02:00").
+func TimezoneLabel(timezone Timezone, names TimezoneNames, at time.Time) (string, error) {
+	offset, err := OffsetAt(timezone, at)
+	if err != nil {
+		return "", err
+	}
+	daylight := timezone.DaylightOffset != nil && offset == *timezone.DaylightOffset && offset != timezone.Offset
+
+	for _, name := range append([]string{timezone.Name}, timezone.Aliases...) {
+		if z, ok := names.Zones[name]; ok {
+			if label := specificName(z, daylight); label != "" {
+				return label, nil
+			}
+		}
+	}
+
+	if m, ok := names.Metazones[timezone.Metazone]; ok {
+		label := specificName(m, daylight)
+		if label == "" {
+			label = m.Generic
+		}
+		if label != "" {
+			return label, nil
+		}
+	}
+
+	city := ExemplarCity(timezone, names)
+	format := names.RegionStandardFormat
+	if daylight {
+		format = names.RegionDaylightFormat
+	}
+	if format == "" {
+		format = names.RegionFormat
+	}
+	if format != "" && city != "" {
+		return strings.Replace(format, "{0}", city, 1), nil
+	}
+
+	return formatGmtOffset(names, offset), nil
+}
+
+func specificName(names MetazoneNames, daylight bool) string {
+	if daylight {
+		return names.Daylight
+	}
+	return names.Standard
+}
+
+// ExemplarCity returns the localized name of the city that identifies a
+// timezone (e.g. "Los Angeles" for "America/Los_Angeles")
+func ExemplarCity(timezone Timezone, names TimezoneNames) string {
+	if city := names.ExemplarCities[timezone.Name]; city != "" {
+		return city
+	}
+	for _, alias := range timezone.Aliases {
+		if city := names.ExemplarCities[alias]; city != "" {
+			return city
+		}
+	}
+
+	// CLDR omits cities that match the zone id
+	if strings.HasPrefix(timezone.Name, "Etc/") {
+		return ""
+	}
+	parts := strings.Split(timezone.Name, "/")
+	return strings.Replace(parts[len(parts)-1], "_", " ", -1)
+}
+
+// formatGmtOffset formats an offset using the CLDR gmt and hour formats
+// of the locale, e.g. "UTC+01:00"
+func formatGmtOffset(names TimezoneNames, offset int) string {
+	if offset == 0 && names.GmtZeroFormat != "" {
+		return names.GmtZeroFormat
+	}
+	gmtFormat := names.GmtFormat
+	if gmtFormat == "" {
+		gmtFormat = "GMT{0}"
+	}
+	hourFormat := names.HourFormat
+	if hourFormat == "" {
+		hourFormat = "+HH:mm;-HH:mm"
+	}
+
+	patterns := strings.SplitN(hourFormat, ";", 2)
+	pattern := patterns[0]
+	if offset < 0 {
+		if len(patterns) > 1 {
+			pattern = patterns[1]
+		}
+		offset = -offset
+	}
+
+	formatted := strings.Replace(pattern, "HH", fmt.Sprintf("%02d", offset/60), 1)
+	formatted = strings.Replace(formatted, "H", fmt.Sprintf("%d", offset/60), 1)
+	formatted = strings.Replace(formatted, "mm", fmt.Sprintf("%02d", offset%60), 1)
+	return strings.Replace(gmtFormat, "{0}", formatted, 1)
+}
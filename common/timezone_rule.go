@@ -0,0 +1,235 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A POSIX TZ rule, as in the footer of TZif files and Timezone.Rule (e.g.
+// "CET-1CEST,M3.5.0,M10.5.0/3"). Offsets are in seconds east of UTC
+type timezoneRule struct {
+	StdOffset int
+	DstOffset int
+	Daylight  bool
+	Start     ruleDate
+	End       ruleDate
+}
+
+// The day and local time of a daylight saving transition
+type ruleDate struct {
+	Kind  byte // 'J' (julian day, no leap day), 'D' (zero based day) or 'M' (month, week, weekday)
+	Day   int
+	Week  int
+	Month int
+	Time  int // seconds since midnight, may be negative or past 24h
+}
+
+func parseTimezoneRule(rule string) (timezoneRule, error) {
+	invalid := fmt.Errorf("Invalid timezone rule[%s]", rule)
+
+	s, ok := skipAbbreviation(rule)
+	if !ok {
+		return timezoneRule{}, invalid
+	}
+	std, s, ok := parseRuleOffset(s)
+	if !ok {
+		return timezoneRule{}, invalid
+	}
+	r := timezoneRule{StdOffset: -std}
+	if s == "" {
+		return r, nil
+	}
+
+	r.Daylight = true
+	if s, ok = skipAbbreviation(s); !ok {
+		return timezoneRule{}, invalid
+	}
+	r.DstOffset = r.StdOffset + 3600
+	if s != "" && s[0] != ',' {
+		var dst int
+		if dst, s, ok = parseRuleOffset(s); !ok {
+			return timezoneRule{}, invalid
+		}
+		r.DstOffset = -dst
+	}
+
+	if s == "" {
+		// The POSIX default, matching the current US rules
+		s = ",M3.2.0,M11.1.0"
+	}
+	if s[0] != ',' {
+		return timezoneRule{}, invalid
+	}
+	if r.Start, s, ok = parseRuleDate(s[1:]); !ok || s == "" || s[0] != ',' {
+		return timezoneRule{}, invalid
+	}
+	if r.End, s, ok = parseRuleDate(s[1:]); !ok || s != "" {
+		return timezoneRule{}, invalid
+	}
+	return r, nil
+}
+
+// TimezoneRuleStandardOffset returns the standard offset, in seconds east
+// of UTC, of a POSIX TZ rule (e.g. 3600 for "CET-1CEST,M3.5.0,M10.5.0/3")
+func TimezoneRuleStandardOffset(rule string) (int, error) {
+	r, err := parseTimezoneRule(rule)
+	if err != nil {
+		return 0, err
+	}
+	return r.StdOffset, nil
+}
+
+// Skips an abbreviation, either alphabetic (e.g. "CET") or quoted (e.g.
+// "<+0530>")
+func skipAbbreviation(s string) (string, bool) {
+	if strings.HasPrefix(s, "<") {
+		i := strings.IndexByte(s, '>')
+		if i < 0 {
+			return "", false
+		}
+		return s[i+1:], true
+	}
+	i := 0
+	for i < len(s) && (s[i] >= 'a' && s[i] <= 'z' || s[i] >= 'A' && s[i] <= 'Z') {
+		i++
+	}
+	return s[i:], i >= 3
+}
+
+// Parses [+-]hh[:mm[:ss]] into seconds. POSIX offsets are positive west
+// of UTC, and the time of a transition uses the same syntax
+func parseRuleOffset(s string) (int, string, bool) {
+	sign := 1
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+
+	seconds := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if i > 0 {
+			if !strings.HasPrefix(s, ":") {
+				break
+			}
+			s = s[1:]
+		}
+		j := 0
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j == 0 {
+			return 0, "", false
+		}
+		n, _ := strconv.Atoi(s[:j])
+		seconds += n * unit
+		s = s[j:]
+	}
+	return sign * seconds, s, true
+}
+
+// Parses "Jn", "n" or "Mm.w.d", with an optional "/time"
+func parseRuleDate(s string) (ruleDate, string, bool) {
+	d := ruleDate{Kind: 'D', Time: 2 * 3600}
+	number := func() (int, bool) {
+		j := 0
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j == 0 {
+			return 0, false
+		}
+		n, _ := strconv.Atoi(s[:j])
+		s = s[j:]
+		return n, true
+	}
+
+	var ok bool
+	switch {
+	case strings.HasPrefix(s, "J"):
+		d.Kind = 'J'
+		s = s[1:]
+		d.Day, ok = number()
+	case strings.HasPrefix(s, "M"):
+		d.Kind = 'M'
+		s = s[1:]
+		if d.Month, ok = number(); ok && strings.HasPrefix(s, ".") {
+			s = s[1:]
+			if d.Week, ok = number(); ok && strings.HasPrefix(s, ".") {
+				s = s[1:]
+				d.Day, ok = number()
+			} else {
+				ok = false
+			}
+		} else {
+			ok = false
+		}
+	default:
+		d.Day, ok = number()
+	}
+	if !ok {
+		return ruleDate{}, "", false
+	}
+
+	if strings.HasPrefix(s, "/") {
+		var t int
+		if t, s, ok = parseRuleOffset(s[1:]); !ok {
+			return ruleDate{}, "", false
+		}
+		d.Time = t
+	}
+	return d, s, true
+}
+
+// The transition in the given year, in seconds since the epoch, for a
+// date expressed in local time at the given offset
+func (d ruleDate) unix(year int, offset int) int64 {
+	var day time.Time
+	switch d.Kind {
+	case 'J':
+		// Day 1 to 365, February 29th is never counted
+		day = time.Date(year, time.January, d.Day, 0, 0, 0, 0, time.UTC)
+		if isLeapYear(year) && d.Day >= 60 {
+			day = day.AddDate(0, 0, 1)
+		}
+	case 'M':
+		// The d'th day (0 is Sunday) of week w (5 is the last) of month m
+		first := time.Date(year, time.Month(d.Month), 1, 0, 0, 0, 0, time.UTC)
+		day = first.AddDate(0, 0, (d.Day-int(first.Weekday())+7)%7+(d.Week-1)*7)
+		for day.Month() != first.Month() {
+			day = day.AddDate(0, 0, -7)
+		}
+	default:
+		day = time.Date(year, time.January, 1+d.Day, 0, 0, 0, 0, time.UTC)
+	}
+	return day.Unix() + int64(d.Time) - int64(offset)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// The offset, in seconds east of UTC, in effect at the instant
+func (r timezoneRule) offsetAt(at time.Time) int {
+	if !r.Daylight {
+		return r.StdOffset
+	}
+	sec := at.Unix()
+	year := at.Add(time.Duration(r.StdOffset) * time.Second).UTC().Year()
+	start := r.Start.unix(year, r.StdOffset)
+	end := r.End.unix(year, r.DstOffset)
+	if start < end {
+		if sec >= start && sec < end {
+			return r.DstOffset
+		}
+		return r.StdOffset
+	}
+	// Southern hemisphere, daylight saving time spans the new year
+	if sec >= end && sec < start {
+		return r.StdOffset
+	}
+	return r.DstOffset
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOffsetAt(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"Europe/Paris", "CET-1CEST,M3.5.0,M10.5.0/3"},
+		{"Europe/London", "GMT0BST,M3.5.0/1,M10.5.0"},
+		{"Europe/Dublin", "IST-1GMT0,M10.5.0,M3.5.0/1"},
+		{"America/New_York", "EST5EDT,M3.2.0,M11.1.0"},
+		{"America/Nuuk", "<-02>2<-01>,M3.5.0/-1,M10.5.0/0"},
+		{"Australia/Sydney", "AEST-10AEDT,M10.1.0,M4.1.0/3"},
+		{"Pacific/Auckland", "NZST-12NZDT,M9.5.0,M4.1.0/3"},
+		{"Asia/Kolkata", "IST-5:30"},
+		{"Asia/Tehran", "<+0330>-3:30"},
+	}
+
+	for _, test := range tests {
+		loc, err := time.LoadLocation(test.name)
+		if err != nil {
+			t.Skipf("No zoneinfo for %s: %s", test.name, err)
+		}
+		tz := Timezone{Name: test.name, Rule: test.rule}
+		for at := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC); at.Year() < 2027; at = at.Add(37 * time.Minute) {
+			got, err := OffsetAt(tz, at)
+			if err != nil {
+				t.Fatalf("OffsetAt(%s) failed: %s", test.name, err)
+			}
+			_, want := at.In(loc).Zone()
+			if got != want/60 {
+				t.Fatalf("OffsetAt(%s, %s) = %d, expected %d", test.name, at, got, want/60)
+			}
+		}
+	}
+}
+
+func TestOffsetAtWithoutRule(t *testing.T) {
+	offset, err := OffsetAt(Timezone{Name: "Etc/GMT-3", Offset: 180}, time.Now())
+	if err != nil || offset != 180 {
+		t.Errorf("OffsetAt without a rule = %d, %v, expected 180", offset, err)
+	}
+
+	daylight := 60
+	if _, err := OffsetAt(Timezone{Name: "Europe/London", DaylightOffset: &daylight}, time.Now()); err == nil {
+		t.Errorf("OffsetAt of a daylight saving zone without a rule should fail")
+	}
+}
+
+func TestParseTimezoneRuleInvalid(t *testing.T) {
+	for _, rule := range []string{"", "CET", "C-1", "CET-1CEST,M3.5", "CET-1CEST,M3.5.0", "<+05-5", "CET-1CEST,M3.5.0,M10.5.0/x"} {
+		if _, err := parseTimezoneRule(rule); err == nil {
+			t.Errorf("parseTimezoneRule(%q) should fail", rule)
+		}
+	}
+}
+
+func TestTimezoneRuleStandardOffset(t *testing.T) {
+	tests := []struct {
+		rule string
+		want int
+	}{
+		{"CET-1CEST,M3.5.0,M10.5.0/3", 3600},
+		{"IST-1GMT0,M10.5.0,M3.5.0/1", 3600},
+		{"<+01>-1<+00>,M3.5.0,M10.5.0/3", 3600},
+		{"EST5EDT,M3.2.0,M11.1.0", -18000},
+		{"<+0330>-3:30", 12600},
+	}
+	for _, test := range tests {
+		if got, err := TimezoneRuleStandardOffset(test.rule); err != nil || got != test.want {
+			t.Errorf("TimezoneRuleStandardOffset(%q) = %d, %v, expected %d", test.rule, got, err, test.want)
+		}
+	}
+}
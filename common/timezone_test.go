@@ -0,0 +1,80 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIanaToWindows(t *testing.T) {
+	timezones := []Timezone{
+		{Name: "Asia/Kolkata", Aliases: []string{"Asia/Calcutta"}},
+		{Name: "America/Los_Angeles", Aliases: []string{"US/Pacific"}},
+	}
+	windowsZones := []WindowsZone{
+		{WindowsId: "Pacific Standard Time (Mexico)", Territory: "MX", Timezones: []string{"America/Tijuana"}},
+		{WindowsId: "Pacific Standard Time", Territory: "CA", Timezones: []string{"America/Vancouver"}},
+		{WindowsId: "UTC-08", Territory: "ZZ", Timezones: []string{"America/Los_Angeles"}},
+		{WindowsId: "Pacific Standard Time", Territory: "US", Timezones: []string{"America/Los_Angeles"}},
+		{WindowsId: "Pacific Standard Time", Territory: "001", Timezones: []string{"America/Los_Angeles"}},
+		{WindowsId: "India Standard Time", Territory: "IN", Timezones: []string{"Asia/Kolkata"}},
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"America/Los_Angeles", "Pacific Standard Time"},
+		{"US/Pacific", "Pacific Standard Time"},
+		{"america/vancouver", "Pacific Standard Time"},
+		{"America/Tijuana", "Pacific Standard Time (Mexico)"},
+		{"Asia/Calcutta", "India Standard Time"},
+		{"Asia/Kolkata", "India Standard Time"},
+		{"Europe/Paris", ""},
+	}
+
+	for _, test := range tests {
+		got, err := IanaToWindows(windowsZones, timezones, test.name)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("IanaToWindows(%q) = %q, expected an error", test.name, got)
+			}
+		} else if err != nil || got != test.want {
+			t.Errorf("IanaToWindows(%q) = %q, %v, expected %q", test.name, got, err, test.want)
+		}
+	}
+}
+
+func TestTimezoneLabel(t *testing.T) {
+	daylight := 60
+	london := Timezone{
+		Name:           "Europe/London",
+		Offset:         0,
+		DaylightOffset: &daylight,
+		Metazone:       "GMT",
+		Rule:           "GMT0BST,M3.5.0/1,M10.5.0",
+	}
+	names := TimezoneNames{
+		Locale:    "en",
+		GmtFormat: "GMT{0}",
+		Metazones: map[string]MetazoneNames{
+			"GMT": {Standard: "Greenwich Mean Time"},
+		},
+		Zones: map[string]MetazoneNames{
+			"Europe/London": {Daylight: "British Summer Time"},
+		},
+	}
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC), "British Summer Time"},
+		{time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC), "Greenwich Mean Time"},
+	}
+	for _, test := range tests {
+		got, err := TimezoneLabel(london, names, test.at)
+		if err != nil || got != test.want {
+			t.Errorf("TimezoneLabel(%s) = %q, %v, expected %q", test.at, got, err, test.want)
+		}
+	}
+}
@@ -15,7 +15,14 @@ func DownloadAll() {
 	download("data/source/languages.json", "https://raw.githubusercontent.com/bdswiss/country-language/master/data.json")
 	download("data/source/countries.csv", "https://raw.githubusercontent.com/datasets/country-codes/2ed03b6993e817845c504ce9626d519376c8acaa/data/country-codes.csv")
 	download("data/source/country-continents.csv", "http://dev.maxmind.com/static/csv/codes/country_continent.csv")
-	download("data/source/cldr-currencies.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-numbers-full/master/main/en-US-POSIX/currencies.json")
+	download("data/source/cldr-currencies.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-numbers-full/master/main/en-001/currencies.json")
+	download("data/source/cldr-windows-zones.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-core/master/supplemental/windowsZones.json")
+	download("data/source/cldr-meta-zones.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-core/master/supplemental/metaZones.json")
+	download("data/source/cldr-currency-data.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-core/master/supplemental/currencyData.json")
+	download("data/source/cldr-likely-subtags.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-core/master/supplemental/likelySubtags.json")
+	download("data/source/cldr-aliases.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-core/master/supplemental/aliases.json")
+	download("data/source/cldr-parent-locales.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-core/master/supplemental/parentLocales.json")
+	download("data/source/cldr-territory-info.json", "https://raw.githubusercontent.com/unicode-cldr/cldr-core/master/supplemental/territoryInfo.json")
 }
 
 // Download the provided url to a temp file, returning the file
@@ -30,7 +37,7 @@ func download(target string, url string) {
 	defer response.Body.Close()
 
 	_, err = io.Copy(tmp, response.Body)
-	util.ExitIfError(err, fmt.Sprintf("Error writing to file %s", tmp))
+	util.ExitIfError(err, fmt.Sprintf("Error writing to file %s", tmp.Name()))
 
 	os.Rename(tmp.Name(), target)
 	fmt.Printf("  -> Stored in %s\n", target)
@@ -4,7 +4,9 @@ package final
 
 import (
 	"fmt"
+	"image/png"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
@@ -21,17 +23,32 @@ type CleansedDataSet struct {
 	Countries               []cleanse.Country
 	CountryContinents       []cleanse.CountryContinent
 	CountryDuties           []cleanse.CountryDuty
+	CountryTelephones       []cleanse.CountryTelephone
 	Currencies              []cleanse.Currency
+	HistoricCurrencies      []cleanse.Currency
 	CurrencySymbols         map[string]cleanse.CurrencySymbols
+	LocaleCurrencySymbols   []cleanse.LocaleCurrencySymbols
+	CurrencyFractions       map[string]cleanse.CurrencyFraction
+	CountryCurrencies       []cleanse.CountryCurrency
+	CountryLanguages        []cleanse.CountryLanguage
+	CountryPopulations      map[string]int64
+	CurrencyRemappings      []cleanse.CurrencyRemapping
 	Numbers                 []cleanse.Number
 	Languages               []cleanse.Language
 	LocaleNames             []cleanse.LocaleName
+	LocaleDisplayNames      []cleanse.LocaleDisplayNames
+	LanguageTagAliases      cleanse.LanguageTagAliases
+	ParentLocales           map[string]string
+	LikelySubtags           map[string]string
 	PaymentMethods          []cleanse.PaymentMethod
 	Provinces               []cleanse.Province
 	ProvinceTranslations    []cleanse.ProvinceTranslation
 	Timezones               []cleanse.Timezone
 	CountryTimezones        []cleanse.CountryTimezone
 	CountryDefaultLanguages []cleanse.CountryDefaultLanguage
+	WindowsZones            []cleanse.WindowsZone
+	TimezoneMetazones       []cleanse.TimezoneMetazone
+	TimezoneNames           []cleanse.TimezoneNames
 }
 
 func Generate() {
@@ -42,10 +59,22 @@ func Generate() {
 		Countries:               cleanse.LoadCountries(),
 		CountryContinents:       cleanse.LoadCountryContinents(),
 		CountryDuties:           cleanse.LoadCountryDuties(),
+		CountryTelephones:       cleanse.LoadCountryTelephones(),
 		Currencies:              cleanse.LoadCurrencies(),
+		HistoricCurrencies:      cleanse.LoadHistoricCurrencies(),
 		CurrencySymbols:         cleanse.LoadCurrencySymbols(),
+		LocaleCurrencySymbols:   cleanse.LoadLocaleCurrencySymbols(),
+		CurrencyFractions:       cleanse.LoadCurrencyFractions(),
+		CountryCurrencies:       cleanse.LoadCountryCurrencies(),
+		CountryLanguages:        cleanse.LoadCountryLanguages(),
+		CountryPopulations:      cleanse.LoadCountryPopulations(),
+		CurrencyRemappings:      cleanse.LoadCurrencyRemappings(),
 		Languages:               cleanse.LoadLanguages(),
 		LocaleNames:             cleanse.LoadLocaleNames(),
+		LocaleDisplayNames:      cleanse.LoadLocaleDisplayNames(),
+		LanguageTagAliases:      cleanse.LoadLanguageTagAliases(),
+		ParentLocales:           cleanse.LoadParentLocales(),
+		LikelySubtags:           cleanse.LoadLikelySubtags(),
 		PaymentMethods:          cleanse.LoadPaymentMethods(),
 		Provinces:               cleanse.LoadProvinces(),
 		ProvinceTranslations:    cleanse.LoadProvinceTranslations(),
@@ -53,22 +82,32 @@ func Generate() {
 		Timezones:               cleanse.LoadTimezones(),
 		CountryTimezones:        cleanse.LoadCountryTimezones(),
 		CountryDefaultLanguages: cleanse.LoadCountryDefaultLanguages(),
+		WindowsZones:            cleanse.LoadWindowsZones(),
+		TimezoneMetazones:       cleanse.LoadTimezoneMetazones(),
+		TimezoneNames:           cleanse.LoadTimezoneNames(),
 	}
 
 	continents := commonContinents(data)
-	countries := commonCountries(data)
 	locales := commonLocales(data)
+	countries := commonCountries(data, locales)
 	regions := createRegions(countries, continents)
 	provinces := createProvinces(data, locales)
+	windowsZones := commonWindowsZones(data)
 
 	writeJson("data/final/carriers.json", commonCarriers(data))
 	writeJson("data/final/carrier-services.json", commonCarrierServices(data))
 	writeJson("data/final/continents.json", continents)
 	writeJson("data/final/payment-methods.json", commonPaymentMethods(data, regions))
-	writeJson("data/final/languages.json", commonLanguages(data))
+	writeJson("data/final/languages.json", commonLanguages(data, locales))
 	writeJson("data/final/locales.json", locales)
-	writeJson("data/final/currencies.json", commonCurrencies(data, locales))
-	writeJson("data/final/timezones.json", commonTimezones(data))
+	writeJson("data/final/language-tag-aliases.json", commonLanguageTagAliases(data))
+	writeJson("data/final/parent-locales.json", data.ParentLocales)
+	writeJson("data/final/currencies.json", commonCurrencies(data, data.Currencies, locales, countries))
+	writeJson("data/final/historic-currencies.json", commonCurrencies(data, data.HistoricCurrencies, locales, countries))
+	writeJson("data/final/currency-remappings.json", commonCurrencyRemappings(data))
+	writeJson("data/final/timezones.json", commonTimezones(data, windowsZones))
+	writeJson("data/final/windows-zones.json", windowsZones)
+	writeJson("data/final/timezone-names.json", commonTimezoneNames(data, locales))
 	writeJson("data/final/countries.json", countries)
 	writeJson("data/final/regions.json", regions)
 	writeJson("data/final/provinces.json", provinces)
@@ -186,10 +225,22 @@ func commonLocales(data CleansedDataSet) []common.Locale {
 
 	unsupportedCountryCodes := common.UnsupportedCountryCodes()
 
+	// Locales of CLDR numbers without a region (e.g. 'pt', whose country
+	// defaults to the language code) are added after those with one
+	// (e.g. 'pt-PT'), so that the latter win in uniqueLocaleIds
+	var implied []common.Locale
+
 	for _, n := range data.Numbers {
 		if common.ContainsIgnoreCase(unsupportedCountryCodes, n.Country) {
 			continue
 		}
+		if n.Script != "" && n.Region != "" {
+			// e.g. 'sr-Latn-BA' is published as a qualified locale, as the
+			// likely script of 'sr-BA' is Cyrillic
+			if script := likelyScript(data.LikelySubtags, n.Language+"-"+n.Region); script != "" && script != n.Script {
+				continue
+			}
+		}
 
 		var originalCountry string
 		if countryMap[n.Country] == "" {
@@ -241,53 +292,271 @@ func commonLocales(data CleansedDataSet) []common.Locale {
 				}
 			}
 
-			separator := ""
-			if n.Separators.Group == "," {
-				separator = ","
-			} else if n.Separators.Group == " " {
-				// Weird encoding from cldr-json
-				separator = " "
-			} else if n.Separators.Group == "." {
-				separator = "."
-			} else if n.Separators.Group == "'" {
-				separator = "'"
-			} else if n.Separators.Group == "’" {
-				separator = "’"
-			} else {
-				fmt.Printf("Invalid group separator[%s]\n", n.Separators.Group)
-				os.Exit(1)
-			}
+			separator := groupSeparator(n)
 
 			language := findLanguageByCode(data.Languages, languageCode)
 			country := findCountryByCode(data.Countries, countryCode)
-			id := common.FormatLocaleId(fmt.Sprintf("%s-%s", language.Iso_639_2, country.Iso_3166_2))
-			name := findLocaleNameById(data.LocaleNames, id)
+			id := common.FormatLocaleId(fmt.Sprintf("%s-%s", language.Iso_639_1, country.Iso_3166_2))
+			name := findLocaleNameById(data, id)
 			if name == "" {
 				name = fmt.Sprintf("%s - %s", language.Name, country.Name)
 			}
 
-			all = append(all, common.Locale{
-				Id:       id,
-				Name:     name,
-				Country:  country.Iso_3166_3,
-				Language: language.Iso_639_2,
+			locale := common.Locale{
+				Id:        id,
+				Name:      name,
+				Country:   country.Iso_3166_3,
+				Language:  language.Iso_639_1,
+				Direction: language.Direction,
 				Numbers: common.LocaleNumbers{
-					Decimal: n.Separators.Decimal,
-					Group:   separator,
+					Decimal:  n.Separators.Decimal,
+					Group:    separator,
+					Currency: n.CurrencyFormat,
 				},
-			})
+			}
+			if n.Region == "" {
+				implied = append(implied, locale)
+			} else {
+				all = append(all, locale)
+			}
 		}
 	}
+	all = append(all, implied...)
 
-	all = append(all, cleanse.LoadLocaleOverrides()...)
+	overrides := []string{}
+	for _, l := range cleanse.LoadLocaleOverrides() {
+		if l.Direction == "" {
+			l.Direction = findLanguageByCode(data.Languages, l.Language).Direction
+		}
+		all = append(all, l)
+		overrides = append(overrides, l.Id)
+	}
+
+	all = append(all, commonQualifiedLocales(data)...)
 
 	uniqueLocales := uniqueLocaleIds(all)
+	for i, l := range uniqueLocales {
+		tag, err := common.ParseLanguageTag(l.Id)
+		if err != nil {
+			fmt.Printf("ERROR: Invalid locale id: %s\n", err)
+			os.Exit(1)
+		}
+		uniqueLocales[i].Id = tag.String()
+		if tag.Script != "" {
+			// e.g. 'az-Arab' is written right-to-left, unlike Azerbaijani
+			uniqueLocales[i].Direction = common.ScriptDirection(tag.Script)
+		}
+		uniqueLocales[i].Script = tag.Script
+		uniqueLocales[i].Region = tag.Region
+		uniqueLocales[i].Variants = tag.Variants
+		uniqueLocales[i].Fallbacks = localeFallbacks(data, tag.String())
+		// e.g. 'fr-FR' takes the symbols of CLDR 'fr'. Overrides keep their
+		// separators, taking only the currency pattern
+		chain := append([]string{tag.String()}, uniqueLocales[i].Fallbacks...)
+		if numbers, ok := resolveLocaleNumbers(data, chain); ok {
+			if common.Contains(overrides, l.Id) {
+				numbers.Decimal = l.Numbers.Decimal
+				numbers.Group = l.Numbers.Group
+			}
+			uniqueLocales[i].Numbers = numbers
+		}
+		if legacy := legacyLocaleId(tag.String()); legacy != tag.String() {
+			uniqueLocales[i].Aliases = []string{legacy}
+		}
+	}
+	uniqueLocales = mergeImpliedScriptLocales(data, uniqueLocales)
 	sortLocales(uniqueLocales)
 
+	displayLocales := localeDisplayIds(uniqueLocales)
+	for i, l := range uniqueLocales {
+		locale := l
+		uniqueLocales[i].Autonym = localeDisplayName(resolveLocaleDisplayNames(data, l.Id), locale)
+		uniqueLocales[i].Names = localizedNames(data, displayLocales, func(names *cleanse.LocaleDisplayNames) string {
+			return localeDisplayName(names, locale)
+		})
+	}
+
 	return uniqueLocales
 }
 
-func commonLanguages(data CleansedDataSet) []common.Language {
+// CLDR locales qualified by a script (e.g. 'sr-Latn-BA', 'az-Cyrl') or by a
+// UN M49 area (e.g. 'es-419'), which the language-country locales above
+// cannot represent. Locales with variants (e.g. 'en-US-POSIX') are not
+// published
+func commonQualifiedLocales(data CleansedDataSet) []common.Locale {
+	var all []common.Locale
+	aliases := commonLanguageTagAliases(data)
+	english := resolveLocaleDisplayNames(data, "en")
+	unsupportedCountryCodes := common.UnsupportedCountryCodes()
+
+	for _, n := range data.Numbers {
+		if n.Variant != "" || (n.Script == "" && !isAreaCode(n.Region)) {
+			continue
+		}
+		languageCode := normalizeLanguageCode(data.Languages, n.Language)
+		if languageCode == "" {
+			continue
+		}
+
+		tag, err := common.CanonicalizeLanguageTag(common.LanguageTag{Language: languageCode, Script: n.Script, Region: n.Region}.String(), aliases)
+		if err != nil {
+			fmt.Printf("ERROR: Invalid CLDR locale: %s\n", err)
+			os.Exit(1)
+		}
+
+		countryCode := ""
+		if tag.Region != "" && !isAreaCode(tag.Region) {
+			countryCode = normalizeCountryCode(data.Countries, tag.Region)
+			if countryCode == "" || common.ContainsIgnoreCase(unsupportedCountryCodes, tag.Region) {
+				continue
+			}
+		}
+
+		language := findLanguageByCode(data.Languages, languageCode)
+		direction := language.Direction
+		if tag.Script != "" {
+			direction = common.ScriptDirection(tag.Script)
+		}
+
+		locale := common.Locale{
+			Id:        tag.String(),
+			Country:   countryCode,
+			Language:  language.Iso_639_1,
+			Script:    tag.Script,
+			Region:    tag.Region,
+			Direction: direction,
+			Numbers: common.LocaleNumbers{
+				Decimal:  n.Separators.Decimal,
+				Group:    groupSeparator(n),
+				Currency: n.CurrencyFormat,
+			},
+		}
+		locale.Name = localeDisplayName(english, locale)
+		if locale.Name == "" {
+			locale.Name = fmt.Sprintf("%s - %s", language.Name, strings.TrimPrefix(locale.Id, language.Iso_639_1+"-"))
+		}
+		all = append(all, locale)
+	}
+	return all
+}
+
+var areaCode = regexp.MustCompile("^[0-9]{3}$")
+
+// UN M49 area codes used as regions in locales, e.g. '419' (Latin America)
+func isAreaCode(region string) bool {
+	return areaCode.MatchString(region)
+}
+
+// The id a locale had before ids were canonical BCP 47 language tags,
+// which dropped repeated subtags (e.g. 'pt-PT' => 'pt')
+func legacyLocaleId(id string) string {
+	distinct := []string{}
+	for _, v := range strings.Split(id, "-") {
+		if !common.ContainsIgnoreCase(distinct, v) {
+			distinct = append(distinct, v)
+		}
+	}
+	return strings.Join(distinct, "-")
+}
+
+// The script the language is most likely written in, for a language tag
+// such as 'zh' or 'zh-TW' (e.g. 'Hant' from 'zh-Hant-TW')
+func likelyScript(likelySubtags map[string]string, id string) string {
+	tag, err := common.ParseLanguageTag(likelySubtags[id])
+	if err != nil {
+		return ""
+	}
+	return tag.Script
+}
+
+// The script qualified form of a language-region locale whose region
+// implies a script other than its language's (e.g. 'zh-TW' =>
+// 'zh-Hant-TW', as 'zh' is likely 'zh-Hans-CN'), otherwise ""
+func impliedScriptLocaleId(likelySubtags map[string]string, id string) string {
+	tag, err := common.ParseLanguageTag(id)
+	if err != nil || tag.Script != "" || tag.Region == "" || len(tag.Variants) > 0 {
+		return ""
+	}
+	script := likelyScript(likelySubtags, tag.Language+"-"+tag.Region)
+	if script == "" || script == likelyScript(likelySubtags, tag.Language) {
+		return ""
+	}
+	tag.Script = script
+	return tag.String()
+}
+
+// The CLDR fallback chain of the locale. A locale whose region implies its
+// script (e.g. 'zh-TW') falls back through its script qualified form, as
+// CLDR lists its data there: 'zh-TW' => ['zh-Hant-TW', 'zh-Hant', 'root']
+func localeFallbacks(data CleansedDataSet, id string) []string {
+	if scripted := impliedScriptLocaleId(data.LikelySubtags, id); scripted != "" {
+		return append([]string{scripted}, common.LocaleFallbacks(scripted, data.ParentLocales)...)
+	}
+	return common.LocaleFallbacks(id, data.ParentLocales)
+}
+
+// Drops script qualified locales whose script is implied by their region
+// (e.g. 'zh-Hant-TW'), listing them as aliases of the language-region
+// locale (e.g. 'zh-TW') so that each locale is published once
+func mergeImpliedScriptLocales(data CleansedDataSet, locales []common.Locale) []common.Locale {
+	indexes := map[string]int{}
+	for i, l := range locales {
+		indexes[l.Id] = i
+	}
+
+	merged := []string{}
+	for _, l := range locales {
+		if l.Script == "" || l.Region == "" || isAreaCode(l.Region) || len(l.Variants) > 0 {
+			continue
+		}
+		id := common.LanguageTag{Language: l.Language, Region: l.Region}.String()
+		i, ok := indexes[id]
+		if ok && impliedScriptLocaleId(data.LikelySubtags, id) == l.Id {
+			locales[i].Aliases = append(locales[i].Aliases, l.Id)
+			merged = append(merged, l.Id)
+		}
+	}
+
+	all := []common.Locale{}
+	for _, l := range locales {
+		if !common.Contains(merged, l.Id) {
+			all = append(all, l)
+		}
+	}
+	return all
+}
+
+func groupSeparator(n cleanse.Number) string {
+	if n.Separators.Group == "," {
+		return ","
+	} else if n.Separators.Group == " " {
+		// Weird encoding from cldr-json
+		return " "
+	} else if n.Separators.Group == "." {
+		return "."
+	} else if n.Separators.Group == "'" {
+		return "'"
+	} else if n.Separators.Group == "’" {
+		return "’"
+	}
+	fmt.Printf("Invalid group separator[%s]\n", n.Separators.Group)
+	os.Exit(1)
+	return ""
+}
+
+func commonLanguageTagAliases(data CleansedDataSet) common.LanguageTagAliases {
+	return common.LanguageTagAliases{
+		Languages: data.LanguageTagAliases.Languages,
+		Scripts:   data.LanguageTagAliases.Scripts,
+		Regions:   data.LanguageTagAliases.Regions,
+		Variants:  data.LanguageTagAliases.Variants,
+	}
+}
+
+func commonLanguages(data CleansedDataSet, locales []common.Locale) []common.Language {
+	displayLocales := localeDisplayIds(locales)
+	languageLocales := cleanse.LoadLanguageLocales()
+
 	var all []common.Language
 	for _, l := range data.Languages {
 		theseCountries := []string{}
@@ -301,15 +570,39 @@ func commonLanguages(data CleansedDataSet) []common.Language {
 		theseLocales := []string{}
 		for _, locale := range l.Locales {
 			// TODO: Validate locale is known
-			theseLocales = append(theseLocales, locale)
+			theseLocales = append(theseLocales, canonicalLocaleId(locales, locale))
 		}
 		sort.Strings(theseLocales)
 
+		code := l.Iso_639_1
+
+		defaultLocale := canonicalLocaleId(locales, languageLocales[code])
+		if defaultLocale == "" {
+			defaultLocale = defaultLocaleIdForLanguage(data, locales, code)
+		}
+		assertLocaleExists(locales, defaultLocale, fmt.Sprintf("language[%s]", code))
+
+		languageName := func(names *cleanse.LocaleDisplayNames) string {
+			if names == nil {
+				return ""
+			}
+			return names.Languages[code]
+		}
+
 		all = append(all, common.Language{
-			Name:      l.Name,
-			Iso_639_2: l.Iso_639_2,
-			Countries: theseCountries,
-			Locales:   theseLocales,
+			Name:          l.Name,
+			Iso_639_1:     l.Iso_639_1,
+			Iso_639_2:     l.Iso_639_1,
+			Iso_639_2B:    l.Iso_639_2B,
+			Iso_639_2T:    l.Iso_639_2T,
+			Iso_639_3:     l.Iso_639_3,
+			Script:        l.Script,
+			Direction:     l.Direction,
+			DefaultLocale: defaultLocale,
+			Autonym:       languageName(resolveLocaleDisplayNames(data, code)),
+			Names:         localizedNames(data, displayLocales, languageName),
+			Countries:     theseCountries,
+			Locales:       theseLocales,
 		})
 	}
 	return all
@@ -357,24 +650,248 @@ func commonPaymentMethods(data CleansedDataSet, regions []common.Region) []commo
 	return all
 }
 
-func commonTimezones(data CleansedDataSet) []common.Timezone {
+func commonTimezones(data CleansedDataSet, windowsZones []common.WindowsZone) []common.Timezone {
 	var all []common.Timezone
 	for _, t := range data.Timezones {
+		transitions := []common.TimezoneTransition{}
+		for _, tr := range t.Transitions {
+			transitions = append(transitions, common.TimezoneTransition{
+				At:           tr.At,
+				Offset:       tr.Offset,
+				Abbreviation: tr.Abbreviation,
+			})
+		}
+
+		metazone := metazoneForTimezone(data, t)
+		// Zones without a Windows id are left without one
+		windowsId, _ := common.IanaToWindows(windowsZones, nil, t.Name)
+
 		all = append(all, common.Timezone{
-			Name:        t.Name,
-			Description: t.Description,
-			Offset:      t.Offset,
+			Name:                 t.Name,
+			Description:          t.Description,
+			Offset:               t.Offset,
+			DaylightOffset:       t.DaylightOffset,
+			Abbreviation:         t.Abbreviation,
+			DaylightAbbreviation: t.DaylightAbbreviation,
+			Aliases:              t.Aliases,
+			WindowsId:            windowsId,
+			Metazone:             metazone,
+			Rule:                 t.Rule,
+			Year:                 t.Year,
+			Transitions:          transitions,
 		})
 	}
 	sortTimezones(all)
 	return all
 }
 
-func commonCurrencies(data CleansedDataSet, locales []common.Locale) []common.Currency {
+func metazoneForTimezone(data CleansedDataSet, timezone cleanse.Timezone) string {
+	for _, m := range data.TimezoneMetazones {
+		if m.Timezone == timezone.Name || common.Contains(timezone.Aliases, m.Timezone) {
+			return m.Metazone
+		}
+	}
+	return ""
+}
+
+// Localized timezone names for each locale, using the CLDR data for the
+// locale itself or else its language. Exemplar cities are keyed by
+// canonical zone name.
+func commonTimezoneNames(data CleansedDataSet, locales []common.Locale) []common.TimezoneNames {
+	var all []common.TimezoneNames
+	for _, l := range locales {
+		var names *cleanse.TimezoneNames
+		for _, id := range append([]string{l.Id}, l.Fallbacks...) {
+			if names = findTimezoneNames(data.TimezoneNames, id); names != nil {
+				break
+			}
+		}
+		if names == nil {
+			continue
+		}
+
+		metazones := map[string]common.MetazoneNames{}
+		for id, m := range names.Metazones {
+			metazones[id] = common.MetazoneNames{
+				Generic:  m.Generic,
+				Standard: m.Standard,
+				Daylight: m.Daylight,
+			}
+		}
+
+		cities := map[string]string{}
+		for zone, city := range names.ExemplarCities {
+			for _, tz := range data.Timezones {
+				if tz.Name == zone || common.Contains(tz.Aliases, zone) {
+					cities[tz.Name] = city
+				}
+			}
+		}
+
+		zones := map[string]common.MetazoneNames{}
+		for zone, z := range names.Zones {
+			for _, tz := range data.Timezones {
+				if tz.Name == zone || common.Contains(tz.Aliases, zone) {
+					zones[tz.Name] = common.MetazoneNames{
+						Generic:  z.Generic,
+						Standard: z.Standard,
+						Daylight: z.Daylight,
+					}
+				}
+			}
+		}
+
+		all = append(all, common.TimezoneNames{
+			Locale:               l.Id,
+			HourFormat:           names.HourFormat,
+			GmtFormat:            names.GmtFormat,
+			GmtZeroFormat:        names.GmtZeroFormat,
+			RegionFormat:         names.RegionFormat,
+			RegionStandardFormat: names.RegionStandardFormat,
+			RegionDaylightFormat: names.RegionDaylightFormat,
+			Metazones:            metazones,
+			Zones:                zones,
+			ExemplarCities:       cities,
+		})
+	}
+	return all
+}
+
+// Ids of the locales we publish names in: each locale and its language
+func localeDisplayIds(locales []common.Locale) []string {
+	ids := []string{}
+	for _, l := range locales {
+		if l.Language != "" && !common.Contains(ids, l.Language) {
+			ids = append(ids, l.Language)
+		}
+		if !common.Contains(ids, l.Id) {
+			ids = append(ids, l.Id)
+		}
+	}
+	return ids
+}
+
+// Names in each display locale. A name is only listed when it differs
+// from the name its fallback chain resolves to (see common.LanguageName).
+// Display locales are visited parents first, so that each is compared
+// against the names that remain.
+func localizedNames(data CleansedDataSet, displayLocales []string, name func(names *cleanse.LocaleDisplayNames) string) map[string]string {
+	fallbacks := map[string][]string{}
+	for _, id := range displayLocales {
+		fallbacks[id] = localeFallbacks(data, id)
+	}
+	ids := append([]string{}, displayLocales...)
+	slice.Sort(ids, func(i, j int) bool {
+		return len(fallbacks[ids[i]]) < len(fallbacks[ids[j]])
+	})
+
+	names := map[string]string{}
+	for _, id := range ids {
+		value := name(resolveLocaleDisplayNames(data, id))
+		if value == "" {
+			continue
+		}
+		inherited := ""
+		for _, parent := range fallbacks[id] {
+			if n, ok := names[parent]; ok {
+				inherited = n
+				break
+			}
+		}
+		if value != inherited {
+			names[id] = value
+		}
+	}
+	return names
+}
+
+// Finds the CLDR names for the locale, resolving through its fallback
+// chain (e.g. 'de-LI' => 'de')
+func resolveLocaleDisplayNames(data CleansedDataSet, locale string) *cleanse.LocaleDisplayNames {
+	for _, id := range append([]string{locale}, localeFallbacks(data, locale)...) {
+		for i, n := range data.LocaleDisplayNames {
+			if common.EqualsIgnoreCase(n.Locale, id) {
+				return &data.LocaleDisplayNames[i]
+			}
+		}
+	}
+	return nil
+}
+
+// The CLDR name of the locale if it has one (e.g. 'British English'),
+// otherwise its language name qualified by its script and region names in
+// the locale pattern (e.g. 'Serbian (Latin, Bosnia & Herzegovina)')
+func localeDisplayName(names *cleanse.LocaleDisplayNames, locale common.Locale) string {
+	if names == nil {
+		return ""
+	}
+	if name, ok := names.Languages[locale.Id]; ok {
+		return name
+	}
+	language := names.Languages[locale.Language]
+	qualifiers := []string{}
+	if locale.Script != "" {
+		if name, ok := names.Languages[locale.Language+"-"+locale.Script]; ok {
+			// e.g. 'Traditional Chinese'
+			language = name
+		} else if script := names.Scripts[locale.Script]; script != "" {
+			qualifiers = append(qualifiers, script)
+		}
+	}
+	if language == "" {
+		return ""
+	}
+	if territory := names.Territories[locale.Region]; territory != "" {
+		qualifiers = append(qualifiers, territory)
+	}
+	if len(qualifiers) == 0 || names.LocalePattern == "" {
+		return language
+	}
+
+	qualifier := qualifiers[0]
+	for _, q := range qualifiers[1:] {
+		qualifier = strings.Replace(strings.Replace(names.LocaleSeparator, "{0}", qualifier, 1), "{1}", q, 1)
+	}
+	return strings.Replace(strings.Replace(names.LocalePattern, "{0}", language, 1), "{1}", qualifier, 1)
+}
+
+func findTimezoneNames(names []cleanse.TimezoneNames, locale string) *cleanse.TimezoneNames {
+	for i, n := range names {
+		if common.EqualsIgnoreCase(n.Locale, locale) {
+			return &names[i]
+		}
+	}
+	return nil
+}
+
+// CLDR lists zones under their historical names (e.g. 'Asia/Calcutta'),
+// which we resolve to the canonical zone
+func commonWindowsZones(data CleansedDataSet) []common.WindowsZone {
+	var all []common.WindowsZone
+	for _, z := range data.WindowsZones {
+		timezones := []string{}
+		for _, name := range z.Timezones {
+			tz := findTimezone(data.Timezones, name)
+			if !common.Contains(timezones, tz.Name) {
+				timezones = append(timezones, tz.Name)
+			}
+		}
+
+		all = append(all, common.WindowsZone{
+			WindowsId: z.WindowsId,
+			Territory: z.Territory,
+			Timezones: timezones,
+		})
+	}
+	return all
+}
+
+func commonCurrencies(data CleansedDataSet, currencies []cleanse.Currency, locales []common.Locale, countries []common.Country) []common.Currency {
 	currencyLocales := cleanse.LoadCurrencyLocales()
+	displayLocales := localeDisplayIds(locales)
 
 	var all []common.Currency
-	for _, c := range data.Currencies {
+	for _, c := range currencies {
 		symbols := data.CurrencySymbols[c.Iso_4217_3]
 
 		commonSymbols := &common.CurrencySymbols{}
@@ -388,37 +905,102 @@ func commonCurrencies(data CleansedDataSet, locales []common.Locale) []common.Cu
 			}
 		}
 
-		defaultLocale := currencyLocales[c.Iso_4217_3]
+		defaultLocale := canonicalLocaleId(locales, currencyLocales[c.Iso_4217_3])
+		defaultLocaleReason := common.DefaultLocaleReasonOverride
 		if defaultLocale == "" {
-			defaultLocale = defaultLocaleIdForCurrency(data, locales, c)
+			defaultLocale = defaultLocaleIdForCurrency(countries, c)
+			defaultLocaleReason = common.DefaultLocaleReasonMostPopulousCountry
+		}
+		if defaultLocale == "" {
+			defaultLocaleReason = ""
+		}
+		assertLocaleExists(locales, defaultLocale, fmt.Sprintf("currency[%s]", c.Iso_4217_3))
+
+		fraction, ok := data.CurrencyFractions[c.Iso_4217_3]
+		if !ok {
+			fraction = data.CurrencyFractions[cleanse.DefaultCurrencyFraction]
 		}
 
 		all = append(all, common.Currency{
-			Name:           c.Name,
-			Iso_4217_3:     c.Iso_4217_3,
-			NumberDecimals: c.NumberDecimals,
-			Symbols:        commonSymbols,
-			DefaultLocale:  defaultLocale,
+			Name:                c.Name,
+			Iso_4217_3:          c.Iso_4217_3,
+			NumberDecimals:      c.NumberDecimals,
+			Digits:              fraction.Digits,
+			Rounding:            fraction.Rounding,
+			CashDigits:          fraction.CashDigits,
+			CashRounding:        fraction.CashRounding,
+			Status:              currencyStatus(data.CountryCurrencies, c.Iso_4217_3),
+			Symbols:             commonSymbols,
+			LocalizedSymbols:    localizedCurrencySymbols(data, displayLocales, c.Iso_4217_3),
+			DefaultLocale:       defaultLocale,
+			DefaultLocaleReason: defaultLocaleReason,
 		})
 	}
 	return all
 }
 
-func commonCountries(data CleansedDataSet) []common.Country {
+// A currency is active while any country uses it as legal tender. Currencies
+// CLDR has no record of are assumed active.
+func currencyStatus(countryCurrencies []cleanse.CountryCurrency, code string) string {
+	known := false
+	for _, cc := range countryCurrencies {
+		if cc.CurrencyCode == code {
+			known = true
+			if cc.To == "" && cc.Tender {
+				return common.CurrencyStatusActive
+			}
+		}
+	}
+	if known {
+		return common.CurrencyStatusHistoric
+	}
+	return common.CurrencyStatusActive
+}
+
+func commonCurrencyRemappings(data CleansedDataSet) []common.CurrencyRemappingProfile {
+	for _, r := range data.CurrencyRemappings {
+		// Exits if the target is not a known currency
+		findCurrencyByCode(data.Currencies, r.TargetCurrency)
+	}
+	return cleanse.ToCurrencyRemappingProfiles(data.CurrencyRemappings)
+}
+
+func commonCountries(data CleansedDataSet, locales []common.Locale) []common.Country {
+	countryLocales := cleanse.LoadCountryLocales()
+
 	var all []common.Country
 	for _, c := range data.Countries {
 		languages := []string{}
 		for _, l := range data.Languages {
 			if common.ContainsIgnoreCase(l.Countries, c.Iso_3166_3) {
-				languages = append(languages, l.Iso_639_2)
+				languages = append(languages, l.Iso_639_1)
 			}
 		}
 
 		timezones := []string{}
+		timezoneLocations := []common.CountryTimezone{}
 		for _, ct := range data.CountryTimezones {
 			if ct.CountryCode == c.Iso_3166_3 {
-				tz := findTimezone(data.Timezones, ct.TimezoneCode)
-				timezones = append(timezones, tz.Name)
+				// Keeps the name listed for the country, which may be an alias
+				// (e.g. America/Anguilla). findTimezone exits if it is unknown
+				findTimezone(data.Timezones, ct.TimezoneCode)
+				name := ct.TimezoneCode
+				if !common.Contains(timezones, name) {
+					timezones = append(timezones, name)
+
+					var coordinates *common.Coordinates
+					if ct.Coordinates != nil {
+						coordinates = &common.Coordinates{
+							Latitude:  ct.Coordinates.Latitude,
+							Longitude: ct.Coordinates.Longitude,
+						}
+					}
+					timezoneLocations = append(timezoneLocations, common.CountryTimezone{
+						Timezone:    name,
+						Coordinates: coordinates,
+						Comments:    ct.Comments,
+					})
+				}
 			}
 		}
 
@@ -430,20 +1012,54 @@ func commonCountries(data CleansedDataSet) []common.Country {
 					fmt.Printf("ERROR: invalid multiple default language codes for country[%s]\n", cl.CountryCode)
 					os.Exit(1)
 				}
-				if !common.Contains(languages, lang.Iso_639_2) {
-					fmt.Printf("ERROR: default language[%s] is not listed in languages for country[%s]\n", lang.Iso_639_2, cl.CountryCode)
+				if !common.Contains(languages, lang.Iso_639_1) {
+					fmt.Printf("ERROR: default language[%s] is not listed in languages for country[%s]\n", lang.Iso_639_1, cl.CountryCode)
 					os.Exit(1)
 				}
-				defaultLanguage = lang.Iso_639_2
+				defaultLanguage = lang.Iso_639_1
 			}
 		}
+
+		// Ordered by population share, largest first
+		languagePopulations := []common.CountryLanguage{}
+		for _, cl := range data.CountryLanguages {
+			if cl.CountryCode == c.Iso_3166_3 && common.Contains(languages, cl.LanguageCode) {
+				languagePopulations = append(languagePopulations, common.CountryLanguage{
+					Language:          cl.LanguageCode,
+					PopulationPercent: cl.PopulationPercent,
+					OfficialStatus:    cl.OfficialStatus,
+				})
+			}
+		}
+		if defaultLanguage == "" {
+			defaultLanguage = defaultLanguageByPopulation(languagePopulations)
+		}
 		if defaultLanguage == "" && len(languages) > 0 {
 			defaultLanguage = languages[0]
 		}
 
+		defaultLocale := canonicalLocaleId(locales, countryLocales[c.Iso_3166_3])
+		if defaultLocale == "" {
+			defaultLocale = defaultLocaleIdForCountry(locales, c.Iso_3166_3, defaultLanguage, languagePopulations)
+		}
+
 		var defaultCurrency string
+		var defaultCurrencyReason string
 		if c.Currency != "" {
 			defaultCurrency = findCurrencyByCode(data.Currencies, c.Currency).Iso_4217_3
+			defaultCurrencyReason = c.CurrencyReason
+		}
+
+		currencyHistory := []common.CountryCurrency{}
+		for _, cc := range data.CountryCurrencies {
+			if cc.CountryCode == c.Iso_3166_3 {
+				currencyHistory = append(currencyHistory, common.CountryCurrency{
+					Currency: cc.CurrencyCode,
+					From:     cc.From,
+					To:       cc.To,
+					Tender:   cc.Tender,
+				})
+			}
 		}
 
 		var defaultDeliveredDuty string
@@ -453,23 +1069,146 @@ func commonCountries(data CleansedDataSet) []common.Country {
 			}
 		}
 
+		var telephone *common.CountryTelephone
+		for _, t := range data.CountryTelephones {
+			if strings.ToUpper(t.CountryCode) == strings.ToUpper(c.Iso_3166_3) {
+				telephone = &common.CountryTelephone{
+					CallingCode:           t.CallingCode,
+					TrunkPrefix:           t.TrunkPrefix,
+					NationalNumberLengths: t.NationalNumberLengths,
+					ExampleNumber:         t.ExampleNumber,
+					AreaCodes:             t.AreaCodes,
+					ExcludedAreaCodes:     excludedAreaCodes(data.CountryTelephones, t),
+				}
+			}
+		}
+
 		sort.Strings(languages)
 		sort.Strings(timezones)
 		all = append(all, common.Country{
-			Name:                 formatCountryName(c.Iso_3166_3, c.Name),
-			Iso_3166_2:           c.Iso_3166_2,
-			Iso_3166_3:           c.Iso_3166_3,
-			MeasurementSystem:    getMeasurementSystem(c.Iso_3166_3),
-			DefaultCurrency:      defaultCurrency,
-			DefaultLanguage:      defaultLanguage,
-			Languages:            languages,
-			Timezones:            timezones,
-			DefaultDeliveredDuty: defaultDeliveredDuty,
+			Name:                  formatCountryName(c.Iso_3166_3, c.Name),
+			Iso_3166_2:            c.Iso_3166_2,
+			Iso_3166_3:            c.Iso_3166_3,
+			Iso_3166_N:            c.Iso_3166_N,
+			M49:                   c.M49,
+			Fips:                  c.Fips,
+			Ioc:                   c.Ioc,
+			Fifa:                  c.Fifa,
+			Itu:                   c.Itu,
+			DialingCode:           c.DialingCode,
+			Tld:                   c.Tld,
+			Capital:               c.Capital,
+			GeonameId:             c.GeonameId,
+			Population:            data.CountryPopulations[c.Iso_3166_3],
+			Flag:                  toCountryFlag(c.Iso_3166_2, c.Iso_3166_3),
+			MeasurementSystem:     getMeasurementSystem(c.Iso_3166_3),
+			DefaultCurrency:       defaultCurrency,
+			DefaultCurrencyReason: defaultCurrencyReason,
+			LocalCurrency:         c.LocalCurrency,
+			Currencies:            c.Currencies,
+			DefaultLanguage:       defaultLanguage,
+			DefaultLocale:         defaultLocale,
+			Languages:             languages,
+			LanguagePopulations:   languagePopulations,
+			Timezones:             timezones,
+			TimezoneLocations:     timezoneLocations,
+			CurrencyHistory:       currencyHistory,
+			DefaultDeliveredDuty:  defaultDeliveredDuty,
+			Telephone:             telephone,
 		})
 	}
+	assertValidExampleTelephoneNumbers(all)
+	assertCountriesHaveTimezones(all)
+	for _, c := range all {
+		assertLocaleExists(locales, c.DefaultLocale, fmt.Sprintf("country[%s]", c.Iso_3166_3))
+	}
+	assertFlagAssetsExist(all)
 	return all
 }
 
+// The country's locale in its default language, otherwise its locale in
+// the most spoken language that has one
+func defaultLocaleIdForCountry(locales []common.Locale, country string, defaultLanguage string, languagePopulations []common.CountryLanguage) string {
+	languages := []string{defaultLanguage}
+	for _, l := range languagePopulations {
+		languages = append(languages, l.Language)
+	}
+	for _, language := range languages {
+		if id := findLocaleId(locales, country, language); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// The language's locale in the country with the most speakers of it
+// (population times population share) that has one
+func defaultLocaleIdForLanguage(data CleansedDataSet, locales []common.Locale, language string) string {
+	countryLanguages := []cleanse.CountryLanguage{}
+	for _, cl := range data.CountryLanguages {
+		if cl.LanguageCode == language {
+			countryLanguages = append(countryLanguages, cl)
+		}
+	}
+	speakers := func(cl cleanse.CountryLanguage) float64 {
+		return float64(data.CountryPopulations[cl.CountryCode]) * cl.PopulationPercent / 100
+	}
+	slice.Sort(countryLanguages[:], func(i, j int) bool {
+		if speakers(countryLanguages[i]) != speakers(countryLanguages[j]) {
+			return speakers(countryLanguages[i]) > speakers(countryLanguages[j])
+		}
+		return countryLanguages[i].CountryCode < countryLanguages[j].CountryCode
+	})
+
+	for _, cl := range countryLanguages {
+		if id := findLocaleId(locales, cl.CountryCode, language); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// The locale for the country in the language, ignoring script qualified
+// alternates (e.g. 'sr-Latn-RS')
+func findLocaleId(locales []common.Locale, country string, language string) string {
+	for _, l := range locales {
+		if l.Script == "" && l.Country == country && l.Language == language {
+			return l.Id
+		}
+	}
+	return ""
+}
+
+// The id of the locale with the given id or alias (e.g. 'fr' => 'fr-FR'),
+// otherwise the id unchanged
+func canonicalLocaleId(locales []common.Locale, id string) string {
+	if l := findLocaleById(locales, id); l.Id != "" {
+		return l.Id
+	}
+	return id
+}
+
+func assertLocaleExists(locales []common.Locale, id string, owner string) {
+	if id != "" && findLocaleById(locales, id).Id == "" {
+		fmt.Printf("ERROR: Default locale[%s] for %s is not in locales.json\n", id, owner)
+		os.Exit(1)
+	}
+}
+
+// The most spoken official (or de facto official) language, otherwise the
+// most spoken language
+func defaultLanguageByPopulation(languagePopulations []common.CountryLanguage) string {
+	for _, l := range languagePopulations {
+		if l.OfficialStatus == common.LanguageStatusOfficial || l.OfficialStatus == common.LanguageStatusDeFactoOfficial {
+			return l.Language
+		}
+	}
+	if len(languagePopulations) > 0 {
+		return languagePopulations[0].Language
+	}
+	return ""
+}
+
 func createRegions(countries []common.Country, continents []common.Continent) []common.Region {
 	regions := []common.Region{}
 
@@ -652,14 +1391,15 @@ func world(countries []common.Country) common.Region {
 		Countries:          codes,
 		Currencies:         currenciesForCountries(countries),
 		Languages:          languagesForCountries(countries),
-		MeasurementSystems: []string{"metric", "imperial"},
+		MeasurementSystems: append([]string{}, common.MeasurementSystems...),
 		Timezones:          timezonesForCountries(countries),
 	}
 }
 
+// Finds the locale by its id or one of its aliases (e.g. 'es' for 'es-ES')
 func findLocaleById(locales []common.Locale, localeId string) common.Locale {
 	for _, l := range locales {
-		if l.Id == localeId {
+		if l.Id == localeId || common.Contains(l.Aliases, localeId) {
 			return l
 		}
 	}
@@ -712,7 +1452,7 @@ func findContinentByCode(continents []cleanse.Continent, code string) cleanse.Co
 
 func findLanguageByCode(languages []cleanse.Language, code string) cleanse.Language {
 	for _, c := range languages {
-		if c.Iso_639_2 == code {
+		if c.Iso_639_1 == code {
 			return c
 		}
 	}
@@ -724,8 +1464,8 @@ func findLanguageByCode(languages []cleanse.Language, code string) cleanse.Langu
 func normalizeLanguageCode(languages []cleanse.Language, code string) string {
 	formatted := strings.ToLower(code)
 	for _, c := range languages {
-		if c.Iso_639_2 == formatted {
-			return c.Iso_639_2
+		if c.Iso_639_1 == formatted {
+			return c.Iso_639_1
 		}
 	}
 	return ""
@@ -733,7 +1473,7 @@ func normalizeLanguageCode(languages []cleanse.Language, code string) string {
 
 func findTimezone(timezones []cleanse.Timezone, name string) cleanse.Timezone {
 	for _, c := range timezones {
-		if strings.ToUpper(c.Name) == strings.ToUpper(name) || strings.ToUpper(c.Description) == strings.ToUpper(name) {
+		if strings.ToUpper(c.Name) == strings.ToUpper(name) || strings.ToUpper(c.Description) == strings.ToUpper(name) || common.ContainsIgnoreCase(c.Aliases, name) {
 			return c
 		}
 	}
@@ -753,21 +1493,57 @@ func findRegion(regions []common.Region, id string) common.Region {
 	return common.Region{}
 }
 
-func findLocaleNameById(names []cleanse.LocaleName, id string) string {
+// The name of the locale, resolving through its fallback chain while the
+// fallback is for the same region (e.g. 'zh-TW' => 'zh-Hant-TW'). A
+// fallback for another region (e.g. 'pt-AO' => 'pt-PT') or for the
+// language alone (e.g. 'fr-CH' => 'fr') does not name the locale
+func findLocaleNameById(data CleansedDataSet, id string) string {
 	localeId := common.FormatLocaleId(id)
-	for _, n := range names {
-		if n.Id == localeId {
-			return n.Name
+	region := ""
+	if tag, err := common.ParseLanguageTag(localeId); err == nil {
+		region = tag.Region
+	}
+
+	for _, candidate := range append([]string{localeId}, localeFallbacks(data, localeId)...) {
+		if tag, err := common.ParseLanguageTag(candidate); err != nil || tag.Region != region {
+			break
+		}
+		for _, n := range data.LocaleNames {
+			if n.Id == candidate {
+				return n.Name
+			}
 		}
 	}
 	return ""
 }
 
+// The CLDR number symbols of the first locale in the chain that lists
+// them (e.g. 'fr-FR' => 'fr')
+func resolveLocaleNumbers(data CleansedDataSet, chain []string) (common.LocaleNumbers, bool) {
+	for _, id := range chain {
+		for _, n := range data.Numbers {
+			if numberLocaleId(n) == id {
+				return common.LocaleNumbers{
+					Decimal:  n.Separators.Decimal,
+					Group:    groupSeparator(n),
+					Currency: n.CurrencyFormat,
+				}, true
+			}
+		}
+	}
+	return common.LocaleNumbers{}, false
+}
+
+// The id of the CLDR locale the numbers are listed for, e.g. 'sr-Latn-BA'
+func numberLocaleId(n cleanse.Number) string {
+	return common.FormatLocaleId(strings.Join(common.FilterNonEmpty([]string{n.Language, n.Script, n.Region, n.Variant}), "-"))
+}
+
 func getMeasurementSystem(iso_3166_3 string) string {
 	if iso_3166_3 == "USA" || iso_3166_3 == "LBR" || iso_3166_3 == "MMR" {
-		return "imperial"
+		return common.MeasurementSystemImperial
 	}
-	return "metric"
+	return common.MeasurementSystemMetric
 }
 
 func formatCountryName(iso3 string, defaultName string) string {
@@ -906,30 +1682,79 @@ func measurementSystemsForCountries(countries []common.Country) []string {
 	return codes
 }
 
-func defaultLocaleIdForCurrency(data CleansedDataSet, locales []common.Locale, currency cleanse.Currency) string {
-	countries := []string{}
-	languages := []string{}
+// Symbols of the currency in each display locale, listed only where they
+// differ from the symbols the locale's fallback chain resolves to, as for
+// localizedNames
+func localizedCurrencySymbols(data CleansedDataSet, displayLocales []string, code string) map[string]common.CurrencySymbols {
+	fallbacks := map[string][]string{}
+	for _, id := range displayLocales {
+		fallbacks[id] = localeFallbacks(data, id)
+	}
+	ids := append([]string{}, displayLocales...)
+	slice.Sort(ids, func(i, j int) bool {
+		return len(fallbacks[ids[i]]) < len(fallbacks[ids[j]])
+	})
 
-	for _, c := range data.Countries {
-		if c.Currency == currency.Iso_4217_3 {
-			countries = append(countries, c.Iso_3166_3)
+	all := map[string]common.CurrencySymbols{}
+	for _, id := range ids {
+		symbols, ok := resolveLocaleCurrencySymbols(data, append([]string{id}, fallbacks[id]...), code)
+		if !ok {
+			continue
+		}
+		var inherited common.CurrencySymbols
+		for _, parent := range fallbacks[id] {
+			if s, ok := all[parent]; ok {
+				inherited = s
+				break
+			}
+		}
+		if symbols != inherited {
+			all[id] = symbols
+		}
+	}
+	return all
+}
 
-			for _, l := range data.Languages {
-				if common.Contains(l.Countries, c.Iso_3166_3) {
-					languages = append(languages, l.Iso_639_2)
-				}
+// Finds the CLDR symbols of the currency in the first locale of the chain
+// that lists them (e.g. 'de-LI' => 'de' => 'root')
+func resolveLocaleCurrencySymbols(data CleansedDataSet, chain []string, code string) (common.CurrencySymbols, bool) {
+	for _, id := range chain {
+		for _, l := range data.LocaleCurrencySymbols {
+			if !common.EqualsIgnoreCase(l.Locale, id) {
+				continue
+			}
+			if s, ok := l.Symbols[code]; ok {
+				return common.CurrencySymbols{
+					Primary: s.Primary,
+					Narrow:  s.Narrow,
+				}, true
 			}
 		}
 	}
+	return common.CurrencySymbols{}, false
+}
 
-	// Return first matching locale
-	for _, l := range locales {
-		if common.Contains(countries, l.Country) && common.Contains(languages, l.Language) {
-			return l.Id
+// The default locale of the most populous country whose default currency
+// it is. Country default locales follow official language data (see
+// defaultLocaleIdForCountry)
+func defaultLocaleIdForCurrency(countries []common.Country, currency cleanse.Currency) string {
+	candidates := []common.Country{}
+	for _, c := range countries {
+		if c.DefaultCurrency == currency.Iso_4217_3 && c.DefaultLocale != "" {
+			candidates = append(candidates, c)
 		}
 	}
+	if len(candidates) == 0 {
+		return ""
+	}
 
-	return ""
+	slice.Sort(candidates[:], func(i, j int) bool {
+		if candidates[i].Population != candidates[j].Population {
+			return candidates[i].Population > candidates[j].Population
+		}
+		return candidates[i].Iso_3166_3 < candidates[j].Iso_3166_3
+	})
+	return candidates[0].DefaultLocale
 }
 
 func assertUniqueRegionIds(regions []common.Region) {
@@ -944,6 +1769,50 @@ func assertUniqueRegionIds(regions []common.Region) {
 	}
 }
 
+func assertCountriesHaveTimezones(countries []common.Country) {
+	missing := []string{}
+	for _, c := range countries {
+		if len(c.Timezones) == 0 {
+			missing = append(missing, c.Iso_3166_3)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Printf("ERROR: Countries without a timezone: %s. Add them to data/original/country-timezones.csv\n", strings.Join(missing, ", "))
+		os.Exit(1)
+	}
+}
+
+// The area codes of the other countries sharing the calling code, for a
+// country without area codes of its own (e.g. USA excludes CAN's 416)
+func excludedAreaCodes(all []cleanse.CountryTelephone, telephone cleanse.CountryTelephone) []string {
+	if len(telephone.AreaCodes) > 0 {
+		return nil
+	}
+	excluded := []string{}
+	for _, t := range all {
+		if t.CallingCode == telephone.CallingCode && t.CountryCode != telephone.CountryCode {
+			excluded = append(excluded, t.AreaCodes...)
+		}
+	}
+	if len(excluded) == 0 {
+		return nil
+	}
+	sort.Strings(excluded)
+	return excluded
+}
+
+func assertValidExampleTelephoneNumbers(countries []common.Country) {
+	for _, c := range countries {
+		if c.Telephone != nil {
+			_, err := common.FormatE164(c, c.Telephone.ExampleNumber)
+			if err != nil {
+				fmt.Printf("ERROR: Invalid example telephone number for country[%s]: %s\n", c.Iso_3166_3, err)
+				os.Exit(1)
+			}
+		}
+	}
+}
+
 func uniqueLocaleIds(locales []common.Locale) []common.Locale {
 	unique := []common.Locale{}
 
@@ -959,8 +1828,10 @@ func uniqueLocaleIds(locales []common.Locale) []common.Locale {
 	return unique
 }
 
+var unsafeIdCharacters = regexp.MustCompile("[^A-Za-z0-9]+")
+
 func generateId(name string) string {
-	safe := regexp.MustCompile("[^A-Za-z0-9]+").ReplaceAllString(name, "-")
+	safe := unsafeIdCharacters.ReplaceAllString(name, "-")
 	return strings.ToLower(safe)
 }
 
@@ -1001,6 +1872,103 @@ func sortTimezones(timezones []common.Timezone) []common.Timezone {
 	return timezones
 }
 
+// The box each size of flag fits in, 3:2 being the most common flag
+// proportion. Flags keep their own proportions (e.g. CHE is square and NPL
+// is taller than wide), so fill the box in one dimension only
+type flagImageSize struct {
+	Size   string
+	Width  int
+	Height int
+}
+
+var flagImageSmall = flagImageSize{Size: "30", Width: 30, Height: 20}
+var flagImageMedium = flagImageSize{Size: "60", Width: 60, Height: 40}
+var flagImageLarge = flagImageSize{Size: "120", Width: 120, Height: 80}
+
+func toCountryFlag(iso_3166_2 string, iso_3166_3 string) common.CountryFlag {
+	emoji, err := common.FlagEmoji(iso_3166_2)
+	if err != nil {
+		fmt.Printf("ERROR: Cannot create flag emoji for country[%s]: %s\n", iso_3166_3, err)
+		os.Exit(1)
+	}
+
+	return common.CountryFlag{
+		Emoji: emoji,
+		Images: common.CountryFlagImages{
+			Small:  toCountryFlagImage(iso_3166_3, flagImageSmall),
+			Medium: toCountryFlagImage(iso_3166_3, flagImageMedium),
+			Large:  toCountryFlagImage(iso_3166_3, flagImageLarge),
+		},
+	}
+}
+
+func toCountryFlagImage(iso_3166_3 string, size flagImageSize) common.CountryFlagImage {
+	url := fmt.Sprintf("https://cdn.flow.io/util/flags/countries/%s/%s/original.png", flagAssetId(iso_3166_3), size.Size)
+
+	return common.CountryFlagImage{
+		Url:    url,
+		Width:  size.Width,
+		Height: size.Height,
+	}
+}
+
+func flagAssetId(iso_3166_3 string) string {
+	return strings.ToLower(iso_3166_3)
+}
+
+// Local copy of the flag assets, laid out as on the CDN:
+// <dir>/<country>/<size>/original.png
+func flagAssetsPath() string {
+	if path := os.Getenv("FLAG_ASSETS"); path != "" {
+		return path
+	}
+	return "data/flags"
+}
+
+// Fails when the flag assets are not found, unless SKIP_FLAG_ASSETS is
+// set to 'true'
+func assertFlagAssetsExist(countries []common.Country) {
+	if os.Getenv("SKIP_FLAG_ASSETS") == "true" {
+		fmt.Printf("WARNING: SKIP_FLAG_ASSETS is set. Skipping flag asset verification\n")
+		return
+	}
+	dir := flagAssetsPath()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		fmt.Printf("ERROR: Flag asset directory %s not found. Set FLAG_ASSETS to a copy of the flag assets, or SKIP_FLAG_ASSETS=true to skip verification\n", dir)
+		os.Exit(1)
+	}
+
+	errors := []string{}
+	for _, c := range countries {
+		for _, s := range []flagImageSize{flagImageSmall, flagImageMedium, flagImageLarge} {
+			path := filepath.Join(dir, flagAssetId(c.Iso_3166_3), s.Size, "original.png")
+			f, err := os.Open(path)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("Country[%s] missing flag asset %s", c.Iso_3166_3, path))
+				continue
+			}
+			config, err := png.DecodeConfig(f)
+			f.Close()
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("Country[%s] flag asset %s is not a valid png: %s", c.Iso_3166_3, path, err))
+			} else if !fitsFlagImageSize(config.Width, config.Height, s) {
+				errors = append(errors, fmt.Sprintf("Country[%s] flag asset %s is %dx%d, expected to fill a %dx%d box in one dimension", c.Iso_3166_3, path, config.Width, config.Height, s.Width, s.Height))
+			}
+		}
+	}
+	if len(errors) > 0 {
+		fmt.Printf("ERROR: Invalid flag assets:\n  %s\n", strings.Join(errors, "\n  "))
+		os.Exit(1)
+	}
+}
+
+func fitsFlagImageSize(width int, height int, size flagImageSize) bool {
+	if width <= 0 || height <= 0 || width > size.Width || height > size.Height {
+		return false
+	}
+	return width == size.Width || height == size.Height
+}
+
 func toPaymentMethodImage(id string, width int, height int, size string) common.PaymentMethodImage {
 	url := fmt.Sprintf("https://cdn.flow.io/util/logos/payment-methods/%s/%s/original.png", id, size)
 
@@ -3,6 +3,7 @@ module github.com/flowcommerce/json-reference
 go 1.15
 
 require (
+	github.com/andybalholm/brotli v1.0.4
 	github.com/bradfitz/slice v0.0.0-20180809154707-2b758aa73013
 	github.com/cpuguy83/go-md2man/v2 v2.0.1 // indirect
 	github.com/flowcommerce/tools v0.0.0-20220104080021-dd447d9a3476
@@ -0,0 +1,230 @@
+package gogen
+
+// Generates a Go package holding our reference data as statically
+// initialized records, with a typed constant for each id (e.g. CountryCAN,
+// CurrencyEUR, LocaleEnUS) so that invalid codes are compile errors and
+// the data is available without any I/O.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/flowcommerce/json-reference/common"
+	"github.com/flowcommerce/tools/util"
+)
+
+// The directory of the generated package when none is given
+const DefaultDir = "reference"
+
+// A list of records, identified by one of their string fields
+type dataset struct {
+	Type    string      // e.g. "Country", naming the id type and constants
+	Plural  string      // e.g. "Countries", naming the records variable
+	IdField string      // e.g. "Iso_3166_3"
+	Records interface{} // a slice of common structs
+}
+
+func Generate(dir string) {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	datasets := []dataset{
+		{"Country", "Countries", "Iso_3166_3", readFinal("countries.json", &[]common.Country{})},
+		{"Currency", "Currencies", "Iso_4217_3", readFinal("currencies.json", &[]common.Currency{})},
+		{"Language", "Languages", "Iso_639_1", readFinal("languages.json", &[]common.Language{})},
+		{"Locale", "Locales", "Id", readFinal("locales.json", &[]common.Locale{})},
+		{"Region", "Regions", "Id", readFinal("regions.json", &[]common.Region{})},
+	}
+
+	source, err := generateSource(filepath.Base(dir), datasets)
+	util.ExitIfError(err, fmt.Sprintf("Failed to generate go source: %s", err))
+
+	target := filepath.Join(dir, "reference.go")
+	fmt.Printf("Writing %s\n", target)
+	err = os.MkdirAll(dir, 0755)
+	util.ExitIfError(err, fmt.Sprintf("Failed to create %s: %s", dir, err))
+	err = ioutil.WriteFile(target, source, 0644)
+	util.ExitIfError(err, fmt.Sprintf("Failed to write %s: %s", target, err))
+}
+
+// Reads a file from the local data/final, so the package holds the data
+// just generated rather than the published data. Returns the slice that
+// 'records' points to
+func readFinal(file string, records interface{}) interface{} {
+	path := fmt.Sprintf("data/final/%s", file)
+	err := json.Unmarshal(common.ReadFile(path), records)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal %s: %s", path, err))
+	return reflect.ValueOf(records).Elem().Interface()
+}
+
+func generateSource(pkg string, datasets []dataset) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by 'go run reference.go gogen'. DO NOT EDIT.\n\n")
+	b.WriteString(fmt.Sprintf("package %s\n\n", pkg))
+	b.WriteString("import (\n\t\"time\"\n\n\t\"github.com/flowcommerce/json-reference/common\"\n)\n")
+
+	for _, d := range datasets {
+		source, err := generateDataset(d)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(source)
+	}
+
+	b.WriteString(`
+func mustParseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+`)
+	return format.Source(b.Bytes())
+}
+
+func generateDataset(d dataset) (string, error) {
+	records := reflect.ValueOf(d.Records)
+	idType := d.Type + "Id"
+	if d.Type == "Country" || d.Type == "Currency" || d.Type == "Language" {
+		idType = d.Type + "Code"
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\ntype %s string\n\nconst (\n", idType))
+	names := map[string]string{}
+	for i := 0; i < records.Len(); i++ {
+		id := records.Index(i).FieldByName(d.IdField).String()
+		name := d.Type + constantName(id)
+		if other, ok := names[name]; ok {
+			return "", fmt.Errorf("%s ids[%s] and [%s] both map to constant %s", d.Type, other, id, name)
+		}
+		names[name] = id
+		b.WriteString(fmt.Sprintf("\t%s %s = %s\n", name, idType, strconv.Quote(id)))
+	}
+	b.WriteString(")\n")
+
+	b.WriteString(fmt.Sprintf("\nvar %s = %s\n", d.Plural, literal(records)))
+
+	b.WriteString(fmt.Sprintf(`
+// Find%[1]s returns the record with the given id
+func Find%[1]s(id %[2]s) (common.%[1]s, bool) {
+	for _, r := range %[3]s {
+		if r.%[4]s == string(id) {
+			return r, true
+		}
+	}
+	return common.%[1]s{}, false
+}
+`, d.Type, idType, d.Plural, d.IdField))
+	return b.String(), nil
+}
+
+// constantName Capitalizes each alphanumeric part of the id, e.g.
+// "en-US" => "EnUS", "zh-Hant-TW" => "ZhHantTW", "es-419" => "Es419"
+func constantName(id string) string {
+	parts := strings.FieldsFunc(id, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// literal Formats the value as a Go composite literal. Zero struct fields
+// are omitted and map keys are sorted so the output is deterministic
+func literal(v reflect.Value) string {
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		return fmt.Sprintf("mustParseTime(%q)", v.Interface().(time.Time).Format(time.RFC3339Nano))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			return "&" + literal(v.Elem())
+		}
+		return fmt.Sprintf("func() %s { v := %s(%s); return &v }()", typeName(v.Type()), typeName(v.Elem().Type()), literal(v.Elem()))
+	case reflect.Struct:
+		fields := []string{}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" || v.Field(i).IsZero() {
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s,\n", v.Type().Field(i).Name, literal(v.Field(i))))
+		}
+		if len(fields) == 0 {
+			return typeName(v.Type()) + "{}"
+		}
+		return fmt.Sprintf("%s{\n%s}", typeName(v.Type()), strings.Join(fields, ""))
+	case reflect.Slice:
+		if v.IsNil() {
+			return "nil"
+		}
+		elements := []string{}
+		for i := 0; i < v.Len(); i++ {
+			elements = append(elements, literal(v.Index(i))+",\n")
+		}
+		return fmt.Sprintf("%s{\n%s}", typeName(v.Type()), strings.Join(elements, ""))
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		entries := []string{}
+		for _, k := range keys {
+			entries = append(entries, fmt.Sprintf("%s: %s,\n", literal(k), literal(v.MapIndex(k))))
+		}
+		return fmt.Sprintf("%s{\n%s}", typeName(v.Type()), strings.Join(entries, ""))
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	fmt.Printf("ERROR: Cannot generate a literal of %s: unsupported kind %s\n", v.Type(), v.Kind())
+	os.Exit(1)
+	return ""
+}
+
+// typeName The name of the type in the generated package, qualified by
+// its package name (e.g. "common.Country" or "[]string")
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		return filepath.Base(t.PkgPath()) + "." + t.Name()
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeName(t.Key()), typeName(t.Elem()))
+	}
+	return t.String()
+}
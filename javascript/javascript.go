@@ -1,10 +1,6 @@
 package javascript
 
 import (
-	"errors"
-	"fmt"
-	"os"
-
 	"github.com/flowcommerce/json-reference/common"
 )
 
@@ -19,6 +15,7 @@ type JavascriptFormat struct {
 type CommonData struct {
 	Countries  []common.Country
 	Currencies []common.Currency
+	Languages  []common.Language
 	Locales    []common.Locale
 }
 
@@ -26,6 +23,7 @@ func Generate() {
 	data := CommonData{
 		Countries:  common.Countries(),
 		Currencies: common.Currencies(),
+		Languages:  common.Languages(),
 		Locales:    common.Locales(),
 	}
 
@@ -36,10 +34,14 @@ func generateFormatsByLocale(data CommonData) map[string]JavascriptFormat {
 	all := map[string]JavascriptFormat{}
 
 	for _, l := range data.Locales {
-		currency, err := findCurrencyByLocale(data, l)
-		if err == nil && currency.Symbols != nil {
+		currency, err := common.LocaleDefaultCurrency(l, data.Locales, data.Languages, data.Countries, data.Currencies)
+		if err != nil {
+			continue
+		}
+		symbols, err := common.LocalizedCurrencySymbols(currency, l)
+		if err == nil {
 			all[l.Id] = JavascriptFormat{
-				Symbol:    currency.Symbols.Primary,
+				Symbol:    symbols.Primary,
 				Decimal:   l.Numbers.Decimal,
 				Group:     l.Numbers.Group,
 				Precision: currency.NumberDecimals,
@@ -48,39 +50,12 @@ func generateFormatsByLocale(data CommonData) map[string]JavascriptFormat {
 		}
 	}
 
-	return all
-}
-
-func findCurrencyByLocale(data CommonData, locale common.Locale) (common.Currency, error) {
-	country := findCountryByIso31663(data, locale.Country)
-	if country.DefaultCurrency == "" {
-		return common.Currency{}, errors.New("Country has no default currency")
-	} else {
-		c := findCurrencyByIso42173(data, country.DefaultCurrency)
-		return c, nil
-	}
-}
-
-func findCountryByIso31663(data CommonData, country string) common.Country {
-	for _, c := range data.Countries {
-		if c.Iso_3166_3 == country {
-			return c
-		}
-	}
-
-	fmt.Printf("ERROR: Country[%s] not found\n", country)
-	os.Exit(1)
-	return common.Country{}
-}
-
-func findCurrencyByIso42173(data CommonData, currency string) common.Currency {
-	for _, c := range data.Currencies {
-		if c.Iso_4217_3 == currency {
-			return c
+	// e.g. 'fr', the id of 'fr-FR' before ids were canonical
+	for alias, id := range common.LocaleIdsByAlias(data.Locales) {
+		if format, ok := all[id]; ok {
+			all[alias] = format
 		}
 	}
 
-	fmt.Printf("ERROR: Currency[%s] not found\n", currency)
-	os.Exit(1)
-	return common.Currency{}
+	return all
 }
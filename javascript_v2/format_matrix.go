@@ -0,0 +1,156 @@
+package javascript_v2
+
+import (
+	"encoding/json"
+
+	"github.com/bradfitz/slice"
+	"github.com/flowcommerce/json-reference/common"
+)
+
+// Formats an amount in any currency for any locale. To format currency C
+// in locale L:
+//   - decimal and group separators are those of locales[L]
+//   - the format is locales[L].formats if listed, otherwise formats. It
+//     follows the CLDR currency pattern of the locale, e.g. "%v %s" in fr
+//   - precision is currencies[C].precision
+//   - the symbol is symbols[i], where i is symbol_sets[locales[L].symbol_set][C]
+//     if listed, otherwise currencies[C].symbol. Each is a pair of indexes:
+//     the primary then the narrow symbol
+//
+// Locales that use the same symbols share a symbol set, so the symbols
+// are only listed once per language in most cases.
+type FormatMatrix struct {
+	Formats    FormatMatrixFormats             `json:"formats"`
+	Symbols    []string                        `json:"symbols"`
+	Currencies map[string]FormatMatrixCurrency `json:"currencies"`
+	SymbolSets []map[string][]int              `json:"symbol_sets"`
+	Locales    map[string]FormatMatrixLocale   `json:"locales"`
+}
+
+// The format of an amount displayed with the currency symbol (e.g.
+// "$1,234.00") or with its ISO 4217 code (e.g. "USD 1,234.00")
+type FormatMatrixFormats struct {
+	Symbol string `json:"symbol"`
+	Code   string `json:"code"`
+}
+
+type FormatMatrixCurrency struct {
+	Precision int   `json:"precision"`
+	Symbol    []int `json:"symbol"`
+}
+
+type FormatMatrixLocale struct {
+	Decimal   string               `json:"decimal"`
+	Group     string               `json:"group"`
+	SymbolSet int                  `json:"symbol_set"`
+	Formats   *FormatMatrixFormats `json:"formats,omitempty"`
+}
+
+var defaultFormats = FormatMatrixFormats{
+	Symbol: "%s%v",
+	Code:   "%s %v",
+}
+
+func generateFormatMatrix(data CommonData) FormatMatrix {
+	matrix := FormatMatrix{
+		Formats:    defaultFormats,
+		Symbols:    []string{},
+		Currencies: map[string]FormatMatrixCurrency{},
+		SymbolSets: []map[string][]int{},
+		Locales:    map[string]FormatMatrixLocale{},
+	}
+
+	symbolIndexes := map[string]int{}
+	symbolIndex := func(symbol string) int {
+		if i, ok := symbolIndexes[symbol]; ok {
+			return i
+		}
+		symbolIndexes[symbol] = len(matrix.Symbols)
+		matrix.Symbols = append(matrix.Symbols, symbol)
+		return symbolIndexes[symbol]
+	}
+
+	currencies := append([]common.Currency{}, data.Currencies...)
+	slice.Sort(currencies[:], func(i, j int) bool {
+		return currencies[i].Iso_4217_3 < currencies[j].Iso_4217_3
+	})
+
+	defaults := map[string][]int{}
+	for _, c := range currencies {
+		primary, narrow := c.Iso_4217_3, c.Iso_4217_3
+		if c.Symbols != nil {
+			primary, narrow = symbolPair(*c.Symbols)
+		}
+		defaults[c.Iso_4217_3] = []int{symbolIndex(primary), symbolIndex(narrow)}
+		matrix.Currencies[c.Iso_4217_3] = FormatMatrixCurrency{
+			Precision: c.NumberDecimals,
+			Symbol:    defaults[c.Iso_4217_3],
+		}
+	}
+
+	locales := append([]common.Locale{}, data.Locales...)
+	slice.Sort(locales[:], func(i, j int) bool {
+		return locales[i].Id < locales[j].Id
+	})
+
+	setIndexes := map[string]int{}
+	for _, l := range locales {
+		set := map[string][]int{}
+		for _, c := range currencies {
+			symbols, err := common.LocalizedCurrencySymbols(c, l)
+			if err != nil {
+				continue
+			}
+			primary, narrow := symbolPair(symbols)
+			pair := []int{symbolIndex(primary), symbolIndex(narrow)}
+			if pair[0] != defaults[c.Iso_4217_3][0] || pair[1] != defaults[c.Iso_4217_3][1] {
+				set[c.Iso_4217_3] = pair
+			}
+		}
+
+		key, _ := json.Marshal(set)
+		index, ok := setIndexes[string(key)]
+		if !ok {
+			index = len(matrix.SymbolSets)
+			setIndexes[string(key)] = index
+			matrix.SymbolSets = append(matrix.SymbolSets, set)
+		}
+
+		locale := FormatMatrixLocale{
+			Decimal:   l.Numbers.Decimal,
+			Group:     l.Numbers.Group,
+			SymbolSet: index,
+		}
+		if formats := localeFormats(l); formats != defaultFormats {
+			locale.Formats = &formats
+		}
+		matrix.Locales[l.Id] = locale
+	}
+	for alias, id := range common.LocaleIdsByAlias(locales) {
+		matrix.Locales[alias] = matrix.Locales[id]
+	}
+
+	return matrix
+}
+
+// The formats following the locale's CLDR currency pattern, e.g. "%v %s"
+// in fr (see common.CurrencyPatternFormats)
+func localeFormats(locale common.Locale) FormatMatrixFormats {
+	if locale.Numbers.Currency == "" {
+		return defaultFormats
+	}
+	symbol, code := common.CurrencyPatternFormats(locale.Numbers.Currency)
+	return FormatMatrixFormats{
+		Symbol: symbol,
+		Code:   code,
+	}
+}
+
+// The primary and narrow symbol, using the primary symbol when there is
+// no narrow one
+func symbolPair(symbols common.CurrencySymbols) (string, string) {
+	if symbols.Narrow == "" {
+		return symbols.Primary, symbols.Primary
+	}
+	return symbols.Primary, symbols.Narrow
+}
@@ -1,10 +1,6 @@
 package javascript_v2
 
 import (
-	"errors"
-	"fmt"
-	"os"
-
 	"github.com/flowcommerce/json-reference/common"
 )
 
@@ -24,6 +20,7 @@ type Symbol struct {
 type CommonData struct {
 	Countries  []common.Country
 	Currencies []common.Currency
+	Languages  []common.Language
 	Locales    []common.Locale
 }
 
@@ -31,69 +28,48 @@ func Generate() {
 	data := CommonData{
 		Countries:  common.Countries(),
 		Currencies: common.Currencies(),
+		Languages:  common.Languages(),
 		Locales:    common.Locales(),
 	}
 
 	common.WriteJson("data/javascript/currency-format.v2.json", generateFormatsByLocale(data))
+	common.WriteJson("data/javascript/currency-format-matrix.v2.json", generateFormatMatrix(data))
 }
 
 func generateFormatsByLocale(data CommonData) map[string]JavascriptFormat {
 	all := map[string]JavascriptFormat{}
 
 	for _, l := range data.Locales {
-		currency, err := findCurrencyByLocale(data, l)
-		if err == nil && currency.Symbols != nil {
-			narrow := currency.Symbols.Narrow
+		currency, err := common.LocaleDefaultCurrency(l, data.Locales, data.Languages, data.Countries, data.Currencies)
+		if err != nil {
+			continue
+		}
+		symbols, err := common.LocalizedCurrencySymbols(currency, l)
+		if err == nil {
+			narrow := symbols.Narrow
 			if narrow == "" {
-				narrow = currency.Symbols.Primary
+				narrow = symbols.Primary
 			}
 
 			all[l.Id] = JavascriptFormat{
 				Symbol: Symbol{
-					Primary: currency.Symbols.Primary,
+					Primary: symbols.Primary,
 					Narrow:  narrow,
 				},
 				Decimal:   l.Numbers.Decimal,
 				Group:     l.Numbers.Group,
 				Precision: currency.NumberDecimals,
-				Format:    "%s%v",
+				Format:    localeFormats(l).Symbol,
 			}
 		}
 	}
 
-	return all
-}
-
-func findCurrencyByLocale(data CommonData, locale common.Locale) (common.Currency, error) {
-	country := findCountryByIso31663(data, locale.Country)
-	if country.DefaultCurrency == "" {
-		return common.Currency{}, errors.New("Country has no default currency")
-	} else {
-		c := findCurrencyByIso42173(data, country.DefaultCurrency)
-		return c, nil
-	}
-}
-
-func findCountryByIso31663(data CommonData, country string) common.Country {
-	for _, c := range data.Countries {
-		if c.Iso_3166_3 == country {
-			return c
-		}
-	}
-
-	fmt.Printf("ERROR: Country[%s] not found\n", country)
-	os.Exit(1)
-	return common.Country{}
-}
-
-func findCurrencyByIso42173(data CommonData, currency string) common.Currency {
-	for _, c := range data.Currencies {
-		if c.Iso_4217_3 == currency {
-			return c
+	// e.g. 'fr', the id of 'fr-FR' before ids were canonical
+	for alias, id := range common.LocaleIdsByAlias(data.Locales) {
+		if format, ok := all[id]; ok {
+			all[alias] = format
 		}
 	}
 
-	fmt.Printf("ERROR: Currency[%s] not found\n", currency)
-	os.Exit(1)
-	return common.Currency{}
+	return all
 }
@@ -0,0 +1,267 @@
+package javascript_v3
+
+// Writes one currency format file per locale, so a storefront only loads
+// the shopper's locale, and a currencies file with the formats shared by
+// all locales. Each file is written as JSON and as an ES module, each with
+// gzip and brotli variants, under a content-hashed name listed in
+// manifest.json (e.g. "locales/fr-FR.js" => "locales/fr-FR.<hash>.js").
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/bradfitz/slice"
+	"github.com/flowcommerce/json-reference/common"
+	"github.com/flowcommerce/tools/util"
+)
+
+const dir = "data/javascript/v3"
+
+// The formats of a locale. Currencies lists the locale's own currencies
+// (those of its country) and the currencies whose symbols in the locale
+// differ from currencies.json; other currencies are formatted as listed
+// there
+type LocaleFormat struct {
+	Locale          string                    `json:"locale"`
+	Decimal         string                    `json:"decimal"`
+	Group           string                    `json:"group"`
+	DefaultCurrency string                    `json:"default_currency,omitempty"`
+	Formats         Formats                   `json:"formats"`
+	Currencies      map[string]CurrencyFormat `json:"currencies"`
+}
+
+// The format of an amount displayed with the currency symbol (e.g.
+// "$1,234.00") or with its ISO 4217 code (e.g. "USD 1,234.00")
+type Formats struct {
+	Symbol string `json:"symbol"`
+	Code   string `json:"code"`
+}
+
+type CurrencyFormat struct {
+	Precision int    `json:"precision"`
+	Symbol    Symbol `json:"symbol"`
+}
+
+type Symbol struct {
+	Primary string `json:"primary"`
+	Narrow  string `json:"narrow"`
+}
+
+// The locales with a format file, used to choose the shopper's locale
+type IndexLocale struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	Country         string `json:"country,omitempty"`
+	Language        string `json:"language,omitempty"`
+	DefaultCurrency string `json:"default_currency,omitempty"`
+}
+
+// A file written under a content-hashed name. The gzip and brotli
+// variants are the same name with a '.gz' or '.br' suffix
+type ManifestEntry struct {
+	File       string `json:"file"`
+	Sha256     string `json:"sha256"`
+	Size       int    `json:"size"`
+	GzipSize   int    `json:"gzip_size"`
+	BrotliSize int    `json:"brotli_size"`
+}
+
+type CommonData struct {
+	Countries  []common.Country
+	Currencies []common.Currency
+	Languages  []common.Language
+	Locales    []common.Locale
+}
+
+func Generate() {
+	data := CommonData{
+		Countries:  common.Countries(),
+		Currencies: common.Currencies(),
+		Languages:  common.Languages(),
+		Locales:    common.Locales(),
+	}
+
+	// Hashed names change with the data, so start from an empty directory
+	err := os.RemoveAll(dir)
+	util.ExitIfError(err, fmt.Sprintf("Failed to remove %s: %s", dir, err))
+	err = os.MkdirAll(filepath.Join(dir, "locales"), 0755)
+	util.ExitIfError(err, fmt.Sprintf("Failed to create %s: %s", dir, err))
+
+	manifest := map[string]ManifestEntry{}
+	currencies := generateCurrencyFormats(data)
+	writeFlavors(manifest, "currencies", "currencies", currencies)
+
+	index := []IndexLocale{}
+	for _, l := range sortedLocales(data) {
+		format := generateLocaleFormat(data, currencies, l)
+		writeFlavors(manifest, fmt.Sprintf("locales/%s", l.Id), "locale", format)
+
+		index = append(index, IndexLocale{
+			Id:              l.Id,
+			Name:            l.Name,
+			Country:         l.Country,
+			Language:        l.Language,
+			DefaultCurrency: format.DefaultCurrency,
+		})
+	}
+	writeFlavors(manifest, "index", "index", index)
+
+	common.WriteJson(filepath.Join(dir, "manifest.json"), manifest)
+}
+
+func sortedLocales(data CommonData) []common.Locale {
+	locales := append([]common.Locale{}, data.Locales...)
+	slice.Sort(locales[:], func(i, j int) bool {
+		return locales[i].Id < locales[j].Id
+	})
+	return locales
+}
+
+// The format of each currency with its own symbols, used in locales that
+// do not list the currency
+func generateCurrencyFormats(data CommonData) map[string]CurrencyFormat {
+	all := map[string]CurrencyFormat{}
+	for _, c := range data.Currencies {
+		symbols := common.CurrencySymbols{Primary: c.Iso_4217_3}
+		if c.Symbols != nil {
+			symbols = *c.Symbols
+		}
+		all[c.Iso_4217_3] = currencyFormat(c, symbols)
+	}
+	return all
+}
+
+// The formats of the locale, following its CLDR currency pattern, and of
+// the currencies it does not share with currencies.json, using the symbols
+// a shopper in the locale expects
+func generateLocaleFormat(data CommonData, currencies map[string]CurrencyFormat, locale common.Locale) LocaleFormat {
+	format := LocaleFormat{
+		Locale:  locale.Id,
+		Decimal: locale.Numbers.Decimal,
+		Group:   locale.Numbers.Group,
+		Formats: Formats{
+			Symbol: "%s%v",
+			Code:   "%s %v",
+		},
+		Currencies: map[string]CurrencyFormat{},
+	}
+	if locale.Numbers.Currency != "" {
+		format.Formats.Symbol, format.Formats.Code = common.CurrencyPatternFormats(locale.Numbers.Currency)
+	}
+	if currency, err := common.LocaleDefaultCurrency(locale, data.Locales, data.Languages, data.Countries, data.Currencies); err == nil {
+		format.DefaultCurrency = currency.Iso_4217_3
+	}
+
+	own := localeCurrencyCodes(data, locale)
+	for _, c := range data.Currencies {
+		symbols, err := common.LocalizedCurrencySymbols(c, locale)
+		if err != nil {
+			symbols = common.CurrencySymbols{Primary: c.Iso_4217_3}
+		}
+		f := currencyFormat(c, symbols)
+		if common.Contains(own, c.Iso_4217_3) || f != currencies[c.Iso_4217_3] {
+			format.Currencies[c.Iso_4217_3] = f
+		}
+	}
+	return format
+}
+
+func currencyFormat(currency common.Currency, symbols common.CurrencySymbols) CurrencyFormat {
+	narrow := symbols.Narrow
+	if narrow == "" {
+		narrow = symbols.Primary
+	}
+	return CurrencyFormat{
+		Precision: currency.NumberDecimals,
+		Symbol: Symbol{
+			Primary: symbols.Primary,
+			Narrow:  narrow,
+		},
+	}
+}
+
+// The currencies of the locale's country: its default, local and other
+// official currencies
+func localeCurrencyCodes(data CommonData, locale common.Locale) []string {
+	country, err := common.LocaleCountry(locale, data.Locales, data.Languages)
+	if err != nil {
+		return nil
+	}
+	codes := []string{}
+	for _, c := range data.Countries {
+		if c.Iso_3166_3 != country {
+			continue
+		}
+		for _, code := range append([]string{c.DefaultCurrency, c.LocalCurrency}, c.Currencies...) {
+			if code != "" && !common.Contains(codes, code) {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return codes
+}
+
+// Writes the data as JSON and as an ES module exporting it, validating it
+// against the schema written for data/javascript/v3/<schema>.json
+func writeFlavors(manifest map[string]ManifestEntry, name string, schema string, data interface{}) {
+	v := common.MarshalJson(filepath.Join(dir, schema+".json"), data)
+
+	writeHashed(manifest, name+".json", v)
+	writeHashed(manifest, name+".js", []byte(fmt.Sprintf("export default %s;\n", v)))
+}
+
+// Writes the content, gzipped and brotli compressed, under the name with
+// the content hash inserted before its extension
+func writeHashed(manifest map[string]ManifestEntry, name string, content []byte) {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	ext := filepath.Ext(name)
+	file := fmt.Sprintf("%s.%s%s", strings.TrimSuffix(name, ext), hash[:16], ext)
+
+	gzipped := compress(content, func(w io.Writer) io.WriteCloser {
+		z, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+		util.ExitIfError(err, fmt.Sprintf("Failed to create gzip writer: %s", err))
+		return z
+	})
+	brotlied := compress(content, func(w io.Writer) io.WriteCloser {
+		return brotli.NewWriterLevel(w, brotli.BestCompression)
+	})
+
+	writeFile(file, content)
+	writeFile(file+".gz", gzipped)
+	writeFile(file+".br", brotlied)
+
+	manifest[name] = ManifestEntry{
+		File:       file,
+		Sha256:     hash,
+		Size:       len(content),
+		GzipSize:   len(gzipped),
+		BrotliSize: len(brotlied),
+	}
+}
+
+func compress(content []byte, writer func(w io.Writer) io.WriteCloser) []byte {
+	var b bytes.Buffer
+	w := writer(&b)
+	_, err := w.Write(content)
+	util.ExitIfError(err, fmt.Sprintf("Failed to compress: %s", err))
+	err = w.Close()
+	util.ExitIfError(err, fmt.Sprintf("Failed to compress: %s", err))
+	return b.Bytes()
+}
+
+func writeFile(name string, content []byte) {
+	target := filepath.Join(dir, name)
+	fmt.Printf("Writing %s\n", target)
+	err := ioutil.WriteFile(target, content, 0644)
+	util.ExitIfError(err, fmt.Sprintf("Failed to write %s: %s", target, err))
+}
@@ -8,8 +8,12 @@ import (
 	"github.com/flowcommerce/json-reference/cleanse"
 	"github.com/flowcommerce/json-reference/download"
 	"github.com/flowcommerce/json-reference/final"
+	"github.com/flowcommerce/json-reference/gogen"
 	"github.com/flowcommerce/json-reference/javascript"
 	"github.com/flowcommerce/json-reference/javascript_v2"
+	"github.com/flowcommerce/json-reference/javascript_v3"
+	"github.com/flowcommerce/json-reference/scala"
+	"github.com/flowcommerce/json-reference/typescript"
 )
 
 func main() {
@@ -42,7 +46,23 @@ func main() {
 				fmt.Println("------------------------------")
 				javascript_v2.Generate()
 
-				fmt.Println("\nDone\n")
+				fmt.Println("\nGenerating javascript v3 models...")
+				fmt.Println("------------------------------")
+				javascript_v3.Generate()
+
+				fmt.Println("\nGenerating typescript declarations...")
+				fmt.Println("------------------------------")
+				typescript.Generate()
+
+				fmt.Println("\nGenerating go package...")
+				fmt.Println("------------------------------")
+				gogen.Generate(gogen.DefaultDir)
+
+				fmt.Println("\nGenerating scala sources...")
+				fmt.Println("------------------------------")
+				scala.Generate()
+
+				fmt.Print("\nDone\n\n")
 				return nil
 			},
 		},
@@ -91,6 +111,49 @@ func main() {
 				return nil
 			},
 		},
+
+		{
+			Name:  "javascript_v3",
+			Usage: "Generates per-locale javascript format files, with an index and content-hashed manifest. Writes to 'data/javascript/v3' directory",
+			Action: func(c *cli.Context) error {
+				javascript_v3.Generate()
+				return nil
+			},
+		},
+
+		{
+			Name:  "typescript",
+			Usage: "Generates typescript declarations for the final and javascript data. Writes to 'data/typescript' directory",
+			Action: func(c *cli.Context) error {
+				typescript.Generate()
+				return nil
+			},
+		},
+
+		{
+			Name:  "gogen",
+			Usage: "Generates a go package with typed constants for our ids and the reference data compiled in. Writes to the 'reference' directory",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "dir",
+					Value: gogen.DefaultDir,
+					Usage: "Directory of the generated package, whose name is the package name",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				gogen.Generate(c.String("dir"))
+				return nil
+			},
+		},
+
+		{
+			Name:  "scala",
+			Usage: "Generates scala case classes and companion objects for lib-reference-scala from the final data. Writes to 'data/scala' directory",
+			Action: func(c *cli.Context) error {
+				scala.Generate()
+				return nil
+			},
+		},
 	}
 
 	app.Run(os.Args)
@@ -0,0 +1,400 @@
+package scala
+
+// Generates the Scala sources of lib-reference-scala from data/final: a
+// case class for each of our types, and for each dataset a companion
+// object with a lazy val per record, 'all' and 'find'. Records are
+// sorted by id and map entries by key so the output only changes with
+// the data.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/flowcommerce/json-reference/common"
+	"github.com/flowcommerce/tools/util"
+)
+
+const (
+	Package = "io.flow.reference.data"
+	dir     = "data/scala/io/flow/reference/data"
+)
+
+// A dataset in data/final, identified by one of its string fields
+type dataset struct {
+	File    string
+	IdField string
+	Records interface{} // pointer to a slice of common structs
+}
+
+var scalaKeywords = []string{
+	"abstract", "case", "catch", "class", "def", "do", "else", "extends",
+	"false", "final", "finally", "for", "forSome", "if", "implicit",
+	"import", "lazy", "match", "new", "null", "object", "override",
+	"package", "private", "protected", "return", "sealed", "super", "this",
+	"throw", "trait", "true", "try", "type", "val", "var", "while", "with",
+	"yield",
+}
+
+func Generate() {
+	datasets := []dataset{
+		{"countries.json", "Iso_3166_3", &[]common.Country{}},
+		{"currencies.json", "Iso_4217_3", &[]common.Currency{}},
+		{"languages.json", "Iso_639_1", &[]common.Language{}},
+		{"locales.json", "Id", &[]common.Locale{}},
+		{"regions.json", "Id", &[]common.Region{}},
+		{"timezones.json", "Name", &[]common.Timezone{}},
+		{"payment-methods.json", "Id", &[]common.PaymentMethod{}},
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	util.ExitIfError(err, fmt.Sprintf("Failed to create %s: %s", dir, err))
+
+	roots := map[reflect.Type]bool{}
+	for _, d := range datasets {
+		path := fmt.Sprintf("data/final/%s", d.File)
+		err = json.Unmarshal(common.ReadFile(path), d.Records)
+		util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal %s: %s", path, err))
+
+		records := reflect.ValueOf(d.Records).Elem()
+		roots[records.Type().Elem()] = true
+		source, err := generateDataset(records, d.IdField)
+		util.ExitIfError(err, fmt.Sprintf("Failed to generate scala for %s: %s", path, err))
+		writeFile(records.Type().Elem().Name()+".scala", source)
+	}
+
+	models := []string{}
+	for _, t := range referencedTypes(roots) {
+		models = append(models, caseClass(t))
+	}
+	writeFile("Models.scala", fmt.Sprintf("%s\n%s", header(), strings.Join(models, "\n")))
+}
+
+func header() string {
+	return fmt.Sprintf("// Generated by 'go run reference.go scala'. Do not edit.\n\npackage %s\n", Package)
+}
+
+// The case class of the records, and its companion object
+func generateDataset(records reflect.Value, idField string) (string, error) {
+	t := records.Type().Elem()
+
+	ids := []string{}
+	byId := map[string]reflect.Value{}
+	for i := 0; i < records.Len(); i++ {
+		id := records.Index(i).FieldByName(idField).String()
+		if _, ok := byId[id]; ok {
+			return "", fmt.Errorf("%s[%s] is listed twice", t.Name(), id)
+		}
+		ids = append(ids, id)
+		byId[id] = records.Index(i)
+	}
+	sort.Strings(ids)
+
+	var b strings.Builder
+	b.WriteString(header())
+	b.WriteString("\n")
+	b.WriteString(caseClass(t))
+	b.WriteString(fmt.Sprintf("\nobject %s {\n", t.Name()))
+
+	names := map[string]string{}
+	for _, id := range ids {
+		name := valName(id)
+		if other, ok := names[name]; ok {
+			return "", fmt.Errorf("%s ids[%s] and [%s] both map to val %s", t.Name(), other, id, name)
+		}
+		names[name] = id
+		b.WriteString(fmt.Sprintf("\n  lazy val %s: %s = %s\n", name, t.Name(), value(byId[id], "  ")))
+	}
+
+	vals := []string{}
+	for _, id := range ids {
+		vals = append(vals, "    "+valName(id))
+	}
+	b.WriteString(fmt.Sprintf("\n  lazy val all: Seq[%s] = Seq(\n%s\n  )\n", t.Name(), strings.Join(vals, ",\n")))
+
+	idName := fieldName(t.Field(fieldIndex(t, idField)))
+	b.WriteString(fmt.Sprintf(`
+  def find(%[1]s: String): Option[%[2]s] = all.find(_.%[1]s.equalsIgnoreCase(%[1]s))
+
+}
+`, idName, t.Name()))
+	return b.String(), nil
+}
+
+// The struct types the roots refer to, excluding the roots, sorted by name
+func referencedTypes(roots map[reflect.Type]bool) []reflect.Type {
+	found := map[reflect.Type]bool{}
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			visit(t.Elem())
+		case reflect.Struct:
+			if t == reflect.TypeOf(time.Time{}) || found[t] {
+				return
+			}
+			found[t] = true
+			for i := 0; i < t.NumField(); i++ {
+				visit(t.Field(i).Type)
+			}
+		}
+	}
+	for t := range roots {
+		visit(t)
+	}
+
+	all := []reflect.Type{}
+	for t := range found {
+		if !roots[t] {
+			all = append(all, t)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name() < all[j].Name()
+	})
+	return all
+}
+
+func caseClass(t reflect.Type) string {
+	params := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !serialized(f) {
+			continue
+		}
+		param := fmt.Sprintf("  %s: %s", fieldName(f), fieldType(f))
+		if d := defaultValue(f); d != "" {
+			param += " = " + d
+		}
+		params = append(params, param)
+	}
+	return fmt.Sprintf("final case class %s(\n%s\n)\n", t.Name(), strings.Join(params, ",\n"))
+}
+
+// A Scala expression constructing the value. Fields equal to their
+// default are left out
+func value(v reflect.Value, indent string) string {
+	t := v.Type()
+	args := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !serialized(f) {
+			continue
+		}
+		fv := v.Field(i)
+		if defaultValue(f) != "" && isEmpty(fv) {
+			continue
+		}
+		arg := fieldValue(fv, indent+"  ")
+		if optional(f) {
+			arg = fmt.Sprintf("Some(%s)", arg)
+		}
+		args = append(args, fmt.Sprintf("%s  %s = %s", indent, fieldName(f), arg))
+	}
+	if len(args) == 0 {
+		return t.Name() + "()"
+	}
+	return fmt.Sprintf("%s(\n%s\n%s)", t.Name(), strings.Join(args, ",\n"), indent)
+}
+
+func fieldValue(v reflect.Value, indent string) string {
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		return fmt.Sprintf("java.time.Instant.parse(%s)", quote(v.Interface().(time.Time).Format(time.RFC3339Nano)))
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		return fieldValue(v.Elem(), indent)
+	case reflect.Struct:
+		return value(v, indent)
+	case reflect.Slice:
+		elements := []string{}
+		for i := 0; i < v.Len(); i++ {
+			elements = append(elements, fieldValue(v.Index(i), indent+"  "))
+		}
+		if k := v.Type().Elem().Kind(); k == reflect.Struct || k == reflect.Ptr {
+			return fmt.Sprintf("Seq(\n%s  %s\n%s)", indent, strings.Join(elements, ",\n"+indent+"  "), indent)
+		}
+		return fmt.Sprintf("Seq(%s)", strings.Join(elements, ", "))
+	case reflect.Map:
+		keys := []string{}
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		entries := []string{}
+		for _, k := range keys {
+			entries = append(entries, fmt.Sprintf("%s -> %s", quote(k), fieldValue(v.MapIndex(reflect.ValueOf(k)), indent)))
+		}
+		return fmt.Sprintf("Map(%s)", strings.Join(entries, ", "))
+	case reflect.String:
+		return quote(v.String())
+	case reflect.Int64:
+		return fmt.Sprintf("%dL", v.Int())
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64) + "d"
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func fieldType(f reflect.StructField) string {
+	if optional(f) {
+		return fmt.Sprintf("Option[%s]", scalaType(f.Type))
+	}
+	return scalaType(f.Type)
+}
+
+func scalaType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "java.time.Instant"
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return scalaType(t.Elem())
+	case reflect.String:
+		return "String"
+	case reflect.Bool:
+		return "Boolean"
+	case reflect.Int, reflect.Int32:
+		return "Int"
+	case reflect.Int64:
+		return "Long"
+	case reflect.Float32, reflect.Float64:
+		return "Double"
+	case reflect.Slice:
+		return fmt.Sprintf("Seq[%s]", scalaType(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("Map[String, %s]", scalaType(t.Elem()))
+	case reflect.Struct:
+		return t.Name()
+	}
+	fmt.Printf("ERROR: Cannot generate a scala type for %s: unsupported kind %s\n", t, t.Kind())
+	os.Exit(1)
+	return ""
+}
+
+// Fields omitted from the json when empty, or pointers, are options.
+// Sequences and maps are empty instead
+func optional(f reflect.StructField) bool {
+	switch f.Type.Kind() {
+	case reflect.Slice, reflect.Map:
+		return false
+	case reflect.Ptr:
+		return true
+	}
+	_, omitempty := jsonName(f)
+	return omitempty
+}
+
+func defaultValue(f reflect.StructField) string {
+	switch {
+	case f.Type.Kind() == reflect.Slice:
+		return "Nil"
+	case f.Type.Kind() == reflect.Map:
+		return "Map.empty"
+	case optional(f):
+		return "None"
+	}
+	return ""
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func serialized(f reflect.StructField) bool {
+	name, _ := jsonName(f)
+	return f.PkgPath == "" && name != "-"
+}
+
+func jsonName(f reflect.StructField) (string, bool) {
+	parts := strings.Split(f.Tag.Get("json"), ",")
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	return name, common.Contains(parts[1:], "omitempty")
+}
+
+// fieldName The json name in camel case (e.g. "iso_3166_3" => "iso31663"),
+// quoted if it is a Scala keyword
+func fieldName(f reflect.StructField) string {
+	name, _ := jsonName(f)
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	camel := strings.Join(parts, "")
+	if common.Contains(scalaKeywords, camel) {
+		return "`" + camel + "`"
+	}
+	return camel
+}
+
+func fieldIndex(t reflect.Type, name string) int {
+	f, ok := t.FieldByName(name)
+	if !ok {
+		fmt.Printf("ERROR: Invalid id field for %s: no field %s\n", t.Name(), name)
+		os.Exit(1)
+	}
+	return f.Index[0]
+}
+
+// valName Capitalizes each alphanumeric part of the id, e.g. "en-US" =>
+// "EnUS", "America/New_York" => "AmericaNewYork", "CAN" => "CAN". The
+// signs of fixed offset zones are spelled out ("Etc/GMT-1" => "EtcGMTMinus1")
+func valName(id string) string {
+	signed := strings.NewReplacer("GMT-", "GMTMinus", "GMT+", "GMTPlus").Replace(id)
+	parts := strings.FieldsFunc(signed, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	name := strings.Join(parts, "")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		return "`" + id + "`"
+	}
+	return name
+}
+
+// quote Formats a Scala string literal
+func quote(value string) string {
+	var b strings.Builder
+	b.WriteString(`"`)
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r < ' ':
+			b.WriteString(fmt.Sprintf(`\u%04x`, r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString(`"`)
+	return b.String()
+}
+
+func writeFile(name string, content string) {
+	target := filepath.Join(dir, name)
+	fmt.Printf("Writing %s\n", target)
+	err := ioutil.WriteFile(target, []byte(content), 0644)
+	util.ExitIfError(err, fmt.Sprintf("Failed to write %s: %s", target, err))
+}
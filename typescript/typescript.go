@@ -0,0 +1,322 @@
+package typescript
+
+// Generates TypeScript declarations for the files in data/final and
+// data/javascript from the Go types that write them, with string literal
+// unions of the ids found in the data (e.g. type CurrencyCode = "AED" | ...)
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flowcommerce/json-reference/common"
+	"github.com/flowcommerce/json-reference/javascript"
+	"github.com/flowcommerce/json-reference/javascript_v2"
+	"github.com/flowcommerce/json-reference/javascript_v3"
+	"github.com/flowcommerce/tools/util"
+)
+
+const target = "data/typescript/reference.d.ts"
+
+// A union of the ids of one kind of record
+type idUnion struct {
+	Name string
+	Ids  []string
+}
+
+// Fields holding ids, typed with the matching union, by Go type and field
+// name. Slices of ids are typed as arrays of the union
+var idFields = map[string]string{
+	"Continent.Countries":         "CountryCode",
+	"Country.Iso_3166_3":          "CountryCode",
+	"Country.DefaultCurrency":     "CurrencyCode",
+	"Country.LocalCurrency":       "CurrencyCode",
+	"Country.Currencies":          "CurrencyCode",
+	"Country.DefaultLanguage":     "LanguageCode",
+	"Country.DefaultLocale":       "LocaleId",
+	"Country.Languages":           "LanguageCode",
+	"Currency.Iso_4217_3":         "CurrencyCode | HistoricCurrencyCode",
+	"Currency.DefaultLocale":      "LocaleId",
+	"Language.Iso_639_1":          "LanguageCode",
+	"Language.Iso_639_2":          "LanguageCode",
+	"Language.DefaultLocale":      "LocaleId",
+	"Language.Countries":          "CountryCode",
+	"Language.Locales":            "LocaleId",
+	"Locale.Id":                   "LocaleId",
+	"Locale.Country":              "CountryCode",
+	"Locale.Language":             "LanguageCode",
+	"PaymentMethod.Regions":       "RegionId",
+	"Province.Country":            "CountryCode",
+	"Region.Id":                   "RegionId",
+	"Region.Countries":            "CountryCode",
+	"Region.Currencies":           "CurrencyCode",
+	"Region.Languages":            "LanguageCode",
+	"TimezoneNames.Locale":        "LocaleId",
+	"IndexLocale.Id":              "LocaleId",
+	"IndexLocale.DefaultCurrency": "CurrencyCode",
+}
+
+// The types written to data/final
+var finalTypes = []interface{}{
+	common.Carrier{},
+	common.CarrierService{},
+	common.Continent{},
+	common.Country{},
+	common.Currency{},
+	common.CurrencyRemappingProfile{},
+	common.Language{},
+	common.LanguageTagAliases{},
+	common.Locale{},
+	common.PaymentMethod{},
+	common.Province{},
+	common.Region{},
+	common.Timezone{},
+	common.TimezoneNames{},
+	common.WindowsZone{},
+}
+
+// The types written to data/javascript, declared in a namespace per
+// generator as their names overlap
+var javascriptTypes = []struct {
+	Namespace string
+	Types     []interface{}
+}{
+	{"JavascriptV1", []interface{}{javascript.JavascriptFormat{}}},
+	{"JavascriptV2", []interface{}{javascript_v2.JavascriptFormat{}, javascript_v2.FormatMatrix{}}},
+	{"JavascriptV3", []interface{}{javascript_v3.LocaleFormat{}, javascript_v3.IndexLocale{}, javascript_v3.ManifestEntry{}}},
+}
+
+func Generate() {
+	countries := []common.Country{}
+	currencies := []common.Currency{}
+	historicCurrencies := []common.Currency{}
+	languages := []common.Language{}
+	locales := []common.Locale{}
+	regions := []common.Region{}
+	readFinal("countries.json", &countries)
+	readFinal("currencies.json", &currencies)
+	readFinal("historic-currencies.json", &historicCurrencies)
+	readFinal("languages.json", &languages)
+	readFinal("locales.json", &locales)
+	readFinal("regions.json", &regions)
+
+	unions := []idUnion{
+		{"CountryCode", countryCodes(countries)},
+		{"CurrencyCode", currencyCodes(currencies)},
+		// The currencies in historic-currencies.json, which share the
+		// Currency interface
+		{"HistoricCurrencyCode", currencyCodes(historicCurrencies)},
+		{"LanguageCode", languageCodes(languages)},
+		{"LocaleId", localeIds(locales)},
+		{"RegionId", regionIds(regions)},
+	}
+
+	writeFile(target, generateDeclarations(unions))
+}
+
+// Reads a file from the local data/final, so the unions match the data
+// just generated rather than the published data
+func readFinal(file string, records interface{}) {
+	path := fmt.Sprintf("data/final/%s", file)
+	err := json.Unmarshal(common.ReadFile(path), records)
+	util.ExitIfError(err, fmt.Sprintf("Failed to unmarshal %s: %s", path, err))
+}
+
+func generateDeclarations(unions []idUnion) string {
+	var b strings.Builder
+	b.WriteString("// Generated by 'go run reference.go typescript'. Do not edit.\n")
+
+	for _, u := range unions {
+		b.WriteString("\n")
+		b.WriteString(declareUnion(u))
+	}
+
+	for _, i := range declareInterfaces(finalTypes, "") {
+		b.WriteString("\n")
+		b.WriteString(i)
+	}
+
+	for _, ns := range javascriptTypes {
+		b.WriteString(fmt.Sprintf("\nexport namespace %s {\n", ns.Namespace))
+		for i, declaration := range declareInterfaces(ns.Types, "  ") {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(declaration)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func declareUnion(u idUnion) string {
+	if len(u.Ids) == 0 {
+		return fmt.Sprintf("export type %s = string;\n", u.Name)
+	}
+	members := []string{}
+	for _, id := range u.Ids {
+		members = append(members, fmt.Sprintf("  | %q", id))
+	}
+	return fmt.Sprintf("export type %s =\n%s;\n", u.Name, strings.Join(members, "\n"))
+}
+
+// Declares an interface for each type and the struct types they refer
+// to, in the order they are first referenced
+func declareInterfaces(roots []interface{}, indent string) []string {
+	queue := []reflect.Type{}
+	for _, r := range roots {
+		queue = append(queue, reflect.TypeOf(r))
+	}
+
+	declared := map[reflect.Type]bool{}
+	all := []string{}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if declared[t] {
+			continue
+		}
+		declared[t] = true
+
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("%sexport interface %s {\n", indent, t.Name()))
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, omitempty := jsonName(f)
+			if name == "" {
+				continue
+			}
+			optional := ""
+			if omitempty || f.Type.Kind() == reflect.Ptr {
+				optional = "?"
+			}
+			b.WriteString(fmt.Sprintf("%s  %s%s: %s;\n", indent, name, optional, tsType(f.Type, idFields[t.Name()+"."+f.Name], &queue)))
+		}
+		b.WriteString(fmt.Sprintf("%s}\n", indent))
+		all = append(all, b.String())
+	}
+	return all
+}
+
+// The json field name and whether it is omitted when empty. Returns an
+// empty name for fields that are not serialized
+func jsonName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" {
+		return "", false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	return name, common.Contains(parts[1:], "omitempty")
+}
+
+// The TypeScript type of a Go type. 'union' names the id union to use for
+// strings, if any. Struct types are queued to be declared
+func tsType(t reflect.Type, union string, queue *[]reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		// RFC 3339 timestamp
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return tsType(t.Elem(), union, queue)
+	case reflect.String:
+		if union != "" {
+			return union
+		}
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		element := tsType(t.Elem(), union, queue)
+		if strings.Contains(element, " ") {
+			return fmt.Sprintf("Array<%s>", element)
+		}
+		return element + "[]"
+	case reflect.Map:
+		return fmt.Sprintf("{ [key: string]: %s }", tsType(t.Elem(), "", queue))
+	case reflect.Struct:
+		*queue = append(*queue, t)
+		return t.Name()
+	}
+	return "unknown"
+}
+
+func writeFile(target string, content string) {
+	fmt.Printf("Writing %s\n", target)
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err == nil {
+		err = ioutil.WriteFile(target, []byte(content), 0644)
+	}
+	if err != nil {
+		fmt.Printf("ERROR: Failed to write %s: %s\n", target, err)
+		os.Exit(1)
+	}
+}
+
+func countryCodes(countries []common.Country) []string {
+	codes := []string{}
+	for _, c := range countries {
+		codes = append(codes, c.Iso_3166_3)
+	}
+	return sortedDistinct(codes)
+}
+
+func currencyCodes(currencies []common.Currency) []string {
+	codes := []string{}
+	for _, c := range currencies {
+		codes = append(codes, c.Iso_4217_3)
+	}
+	return sortedDistinct(codes)
+}
+
+func languageCodes(languages []common.Language) []string {
+	codes := []string{}
+	for _, l := range languages {
+		codes = append(codes, l.Iso_639_1)
+	}
+	return sortedDistinct(codes)
+}
+
+func localeIds(locales []common.Locale) []string {
+	ids := []string{}
+	for _, l := range locales {
+		ids = append(ids, l.Id)
+	}
+	return sortedDistinct(ids)
+}
+
+func regionIds(regions []common.Region) []string {
+	ids := []string{}
+	for _, r := range regions {
+		ids = append(ids, r.Id)
+	}
+	return sortedDistinct(ids)
+}
+
+func sortedDistinct(values []string) []string {
+	distinct := []string{}
+	for _, v := range common.FilterNonEmpty(values) {
+		if !common.Contains(distinct, v) {
+			distinct = append(distinct, v)
+		}
+	}
+	sort.Strings(distinct)
+	return distinct
+}